@@ -17,4 +17,9 @@ output "passbolt_password_value" {
 #   ...
 #   master_username = data.passbolt_password.by_id.username
 #   master_password = data.passbolt_password.by_id.password
-# }
\ No newline at end of file
+# }
+# Look up the same logical secret by name in every environment instead of hardcoding UUIDs.
+data "passbolt_password" "by_name" {
+  name        = "db-admin"
+  folder_path = "/application_A/prod"
+}
@@ -0,0 +1,22 @@
+# List every password below a folder path, including subfolders.
+data "passbolt_passwords" "prod" {
+  folder_path = "/application_A/prod"
+  recursive   = true
+}
+
+output "prod_password_ids" {
+  value = { for p in data.passbolt_passwords.prod.passwords : p.name => p.id }
+}
+
+# Narrow the list down and decrypt the matching secrets.
+data "passbolt_passwords" "db_admins" {
+  name_regex        = "^db-"
+  username          = "admin"
+  shared_with_group = "DevOps"
+  include_secrets   = true
+}
+
+output "db_admin_passwords" {
+  sensitive = true
+  value     = { for p in data.passbolt_passwords.db_admins.passwords : p.name => p.password }
+}
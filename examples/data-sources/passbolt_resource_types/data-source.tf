@@ -0,0 +1,22 @@
+data "passbolt_resource_types" "all" {}
+
+output "v5_resource_types" {
+  value = [for t in data.passbolt_resource_types.all.resource_types : t.slug if t.version == "v5" && !t.deprecated]
+}
+
+# Fail the plan before rolling out encrypted metadata on an instance that does not support it.
+resource "passbolt_password" "example" {
+  name                = "Payments API"
+  username            = "payments"
+  uri                 = "https://payments.example.com"
+  password_wo         = var.payments_password
+  password_wo_version = 1
+  metadata_type       = "v5"
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.passbolt_resource_types.all.slugs, "v5-default")
+      error_message = "The Passbolt server does not support v5-default resources."
+    }
+  }
+}
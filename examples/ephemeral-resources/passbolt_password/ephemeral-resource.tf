@@ -0,0 +1,12 @@
+ephemeral "passbolt_password" "db_admin" {
+  id = "1234-5678-90ab-cdef-1234-5678-90ab-cdef"
+}
+
+# Feed the secret into a write-only argument without persisting it in state:
+
+# resource "aws_db_instance" "main" {
+#   ...
+#   username            = ephemeral.passbolt_password.db_admin.username
+#   password_wo         = ephemeral.passbolt_password.db_admin.password
+#   password_wo_version = 1
+# }
@@ -0,0 +1,19 @@
+ephemeral "passbolt_password" "break_glass" {
+  id = passbolt_password.break_glass.id
+}
+
+ephemeral "passbolt_totp_code" "break_glass" {
+  id = passbolt_password.break_glass.id
+}
+
+# Hand both factors to another ephemeral context, for example a provider block:
+
+# provider "vault" {
+#   auth_login_userpass {
+#     username = ephemeral.passbolt_password.break_glass.username
+#     password = ephemeral.passbolt_password.break_glass.password
+#   }
+#   ...
+# }
+#
+# Read ephemeral.passbolt_totp_code.break_glass.code before expires_at.
@@ -0,0 +1,8 @@
+list "passbolt_folder" "application_a" {
+  provider = passbolt
+
+  config {
+    folder_path = "/application_A"
+    recursive   = true
+  }
+}
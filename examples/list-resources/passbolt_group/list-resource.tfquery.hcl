@@ -0,0 +1,7 @@
+list "passbolt_group" "platform" {
+  provider = passbolt
+
+  config {
+    name_prefix = "platform-"
+  }
+}
@@ -0,0 +1,11 @@
+# Run `terraform query -generate-config-out=generated.tf` to write import
+# blocks and configuration for every password in /application_A/prod.
+list "passbolt_password" "prod" {
+  provider         = passbolt
+  include_resource = true
+
+  config {
+    folder_path = "/application_A/prod"
+    recursive   = true
+  }
+}
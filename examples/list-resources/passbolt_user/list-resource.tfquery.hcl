@@ -0,0 +1,8 @@
+list "passbolt_user" "admins" {
+  provider = passbolt
+
+  config {
+    role             = "admin"
+    include_inactive = true
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = passbolt_folder.example
+  identity = {
+    id = "2a61bc5d-bbbb-aaaa-cccc-123456789abc"
+  }
+}
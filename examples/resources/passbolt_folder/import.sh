@@ -1,2 +1,5 @@
 # Folder can be imported by specifying the UUID.
 terraform import passbolt_folder.example 2a61bc5d-bbbb-aaaa-cccc-123456789abc
+
+# Or by absolute path, or by name when the name is unique.
+terraform import passbolt_folder.example /application_A/prod
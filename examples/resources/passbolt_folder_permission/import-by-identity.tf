@@ -0,0 +1,8 @@
+import {
+  to = passbolt_folder_permission.example
+  identity = {
+    folder_id      = "1111aaaa-2222-bbbb-3333-cccc4444dddd"
+    principal_type = "group"
+    principal_id   = "5d72bc5d-eeee-dddd-cccc-fedcba987654"
+  }
+}
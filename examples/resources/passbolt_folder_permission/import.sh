@@ -1,2 +1,9 @@
-# Folder permission can be imported using folder_id:group_name
-terraform import passbolt_folder_permission.example 1111aaaa-2222-bbbb-3333-cccc4444dddd:DevOps
+# Folder group permission can be imported using folder_id:group:group_name.
+# The original folder_id:group_name format keeps working.
+terraform import passbolt_folder_permission.example 1111aaaa-2222-bbbb-3333-cccc4444dddd:group:Developers
+
+# Folder user permission can be imported using folder_id:user:username
+terraform import passbolt_folder_permission.contractor 1111aaaa-2222-bbbb-3333-cccc4444dddd:user:contractor@example.com
+
+# Or by absolute folder path instead of the folder UUID.
+terraform import passbolt_folder_permission.example /application_A/prod:group:Developers
@@ -7,4 +7,10 @@ resource "passbolt_folder_permission" "example" {
   folder_id  = passbolt_folder.example.id
   group_name = "Developers"
   permission = "update"
-}
\ No newline at end of file
+}
+resource "passbolt_folder_permission" "contractor" {
+  folder_id  = passbolt_folder.example.id
+  username   = "contractor@example.com"
+  permission = "read"
+  propagate  = "recursive"
+}
@@ -0,0 +1,6 @@
+import {
+  to = passbolt_folder_permissions.example
+  identity = {
+    id = "1111aaaa-2222-bbbb-3333-cccc4444dddd"
+  }
+}
@@ -0,0 +1,5 @@
+# Folder permissions can be imported by specifying the folder UUID.
+terraform import passbolt_folder_permissions.example 1111aaaa-2222-bbbb-3333-cccc4444dddd
+
+# Or by absolute folder path, or by name when the name is unique.
+terraform import passbolt_folder_permissions.example /application_A/prod
@@ -0,0 +1,22 @@
+resource "passbolt_folder" "example" {
+  name = "terraform_test_folder"
+}
+
+resource "passbolt_folder_permissions" "example" {
+  folder_id = passbolt_folder.example.id
+
+  permissions = [
+    {
+      group_name = "Administrators"
+      permission = "owner"
+    },
+    {
+      group_name = "Developers"
+      permission = "update"
+    },
+    {
+      username   = "contractor@example.com"
+      permission = "read"
+    },
+  ]
+}
@@ -0,0 +1,6 @@
+import {
+  to = passbolt_group.example
+  identity = {
+    id = "5d72bc5d-eeee-dddd-cccc-fedcba987654"
+  }
+}
@@ -1,2 +1,5 @@
 # Group can be imported by specifying the UUID.
 terraform import passbolt_group.example 5d72bc5d-eeee-dddd-cccc-fedcba987654
+
+# Or by name.
+terraform import passbolt_group.example DevOps
@@ -1,2 +1,5 @@
 # Password can be imported by specifying the UUID.
 terraform import passbolt_password.example 3c29bc5d-ffff-cccc-bbbb-abcdefabcdef
+
+# Or by folder path and name. Use /name for a password at the top level.
+terraform import passbolt_password.example /application_A/prod/db-admin
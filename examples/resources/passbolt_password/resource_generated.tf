@@ -0,0 +1,17 @@
+# Let the provider generate the secret. The value is never stored in Terraform state.
+resource "passbolt_password" "example_generated" {
+  name          = "Terraform Service Account"
+  username      = "svc-terraform"
+  uri           = "https://service.example.com"
+  folder_parent = "Terraform Folders"
+
+  generate {
+    length             = 40
+    exclude_look_alike = true
+
+    # Change any keeper value to rotate the generated secret.
+    keepers = {
+      rotation = "2026-10"
+    }
+  }
+}
@@ -0,0 +1,32 @@
+# Several endpoints, an icon, and custom fields in one v5 entry.
+variable "payments_webhook_secret" {
+  description = "Webhook signing secret kept in an encrypted custom field."
+  type        = string
+  sensitive   = true
+}
+
+resource "passbolt_password" "example_v5_fields" {
+  name                = "Payments API"
+  username            = "payments"
+  password_wo         = var.terraform_admin_password
+  password_wo_version = 1
+  metadata_type       = "v5"
+
+  uris = [
+    "https://payments.example.com",
+    "https://payments-dr.example.com",
+  ]
+
+  icon  = 42
+  color = "#1F6FEB"
+
+  custom_fields = {
+    region = {
+      value = "eu-west-1"
+    }
+    webhook_secret = {
+      type         = "password"
+      secret_value = var.payments_webhook_secret
+    }
+  }
+}
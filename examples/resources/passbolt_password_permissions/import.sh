@@ -0,0 +1,5 @@
+# Password permissions can be imported by specifying the password UUID.
+terraform import passbolt_password_permissions.example 3c29bc5d-ffff-cccc-bbbb-abcdefabcdef
+
+# Or by folder path and name. Use /name for a password at the top level.
+terraform import passbolt_password_permissions.example /application_A/prod/db-admin
@@ -0,0 +1,24 @@
+resource "passbolt_password" "example" {
+  name     = "db-admin"
+  username = "admin"
+  password = "super-secret"
+}
+
+resource "passbolt_password_permissions" "example" {
+  resource_id = passbolt_password.example.id
+
+  permissions = [
+    {
+      group_name = "Administrators"
+      permission = "owner"
+    },
+    {
+      group_name = "DevOps"
+      permission = "update"
+    },
+    {
+      username   = "operator@example.com"
+      permission = "read"
+    },
+  ]
+}
@@ -0,0 +1,6 @@
+import {
+  to = passbolt_resource.example
+  identity = {
+    id = "3c29bc5d-ffff-cccc-bbbb-abcdefabcdef"
+  }
+}
@@ -0,0 +1,2 @@
+# Resource can be imported by specifying the UUID.
+terraform import passbolt_resource.example 3c29bc5d-ffff-cccc-bbbb-abcdefabcdef
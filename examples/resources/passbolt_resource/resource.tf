@@ -0,0 +1,35 @@
+resource "passbolt_resource" "database" {
+  resource_type = "v5-default"
+  folder_parent = "application_A"
+
+  metadata = {
+    name     = "Production database"
+    username = "app"
+    uris     = ["postgres://db.example.com:5432", "postgres://db-replica.example.com:5432"]
+  }
+
+  secret_json = jsonencode({
+    password    = var.database_password
+    description = "Rotated by the platform team"
+  })
+  secret_json_version = 1
+}
+
+resource "passbolt_resource" "ci_totp" {
+  resource_type = "v5-totp-standalone"
+
+  metadata = {
+    name = "CI account 2FA"
+    uris = ["https://ci.example.com"]
+  }
+
+  secret_json = jsonencode({
+    totp = {
+      secret_key = var.ci_totp_seed
+      algorithm  = "SHA1"
+      digits     = 6
+      period     = 30
+    }
+  })
+  secret_json_version = 1
+}
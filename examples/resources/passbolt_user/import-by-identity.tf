@@ -0,0 +1,6 @@
+import {
+  to = passbolt_user.example
+  identity = {
+    id = "9999ffff-8888-7777-6666-5555eeeeeeee"
+  }
+}
@@ -1,2 +1,5 @@
 # User can be imported by UUID.
 terraform import passbolt_user.example 9999ffff-8888-7777-6666-5555eeeeeeee
+
+# Or by email address.
+terraform import passbolt_user.example operator@example.com
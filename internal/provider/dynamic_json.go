@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// errUnknownDynamicValue is returned when part of a dynamic value is not known yet.
+var errUnknownDynamicValue = errors.New("value is not known yet")
+
+// dynamicToJSON converts a Terraform value to the generic form produced by encoding/json.
+func dynamicToJSON(value attr.Value) (any, error) {
+	if value.IsUnknown() {
+		return nil, errUnknownDynamicValue
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return dynamicToJSON(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		number, _ := v.ValueBigFloat().Float64()
+
+		return number, nil
+	case basetypes.Int64Value:
+		return float64(v.ValueInt64()), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.ObjectValue:
+		return dynamicMapToJSON(v.Attributes())
+	case basetypes.MapValue:
+		return dynamicMapToJSON(v.Elements())
+	case basetypes.ListValue:
+		return dynamicListToJSON(v.Elements())
+	case basetypes.SetValue:
+		return dynamicListToJSON(v.Elements())
+	case basetypes.TupleValue:
+		return dynamicListToJSON(v.Elements())
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", value.Type(nil))
+	}
+}
+
+func dynamicMapToJSON(elements map[string]attr.Value) (map[string]any, error) {
+	result := make(map[string]any, len(elements))
+	for key, element := range elements {
+		value, err := dynamicToJSON(element)
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+func dynamicListToJSON(elements []attr.Value) ([]any, error) {
+	result := make([]any, 0, len(elements))
+	for _, element := range elements {
+		value, err := dynamicToJSON(element)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+// jsonToDynamic converts a value decoded by encoding/json to a Terraform value. Objects become object values and
+// arrays become tuples, which is what Terraform infers for the same literal in configuration.
+func jsonToDynamic(value any) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case map[string]any:
+		attributeTypes := make(map[string]attr.Type, len(v))
+		attributes := make(map[string]attr.Value, len(v))
+		for key, element := range v {
+			converted, err := jsonToDynamic(element)
+			if err != nil {
+				return nil, err
+			}
+
+			attributeTypes[key] = converted.Type(nil)
+			attributes[key] = converted
+		}
+
+		object, diags := types.ObjectValue(attributeTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building object: %v", diags)
+		}
+
+		return object, nil
+	case []any:
+		elementTypes := make([]attr.Type, 0, len(v))
+		elements := make([]attr.Value, 0, len(v))
+		for _, element := range v {
+			converted, err := jsonToDynamic(element)
+			if err != nil {
+				return nil, err
+			}
+
+			elementTypes = append(elementTypes, converted.Type(nil))
+			elements = append(elements, converted)
+		}
+
+		tuple, diags := types.TupleValue(elementTypes, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("building tuple: %v", diags)
+		}
+
+		return tuple, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value %T", value)
+	}
+}
+
+// jsonObjectsEquivalent compares two JSON objects, treating null and empty string members as absent.
+func jsonObjectsEquivalent(a, b map[string]any) bool {
+	normalizedA, errA := json.Marshal(withoutEmptyMembers(a))
+	normalizedB, errB := json.Marshal(withoutEmptyMembers(b))
+
+	return errA == nil && errB == nil && bytes.Equal(normalizedA, normalizedB)
+}
+
+func withoutEmptyMembers(object map[string]any) map[string]any {
+	result := make(map[string]any, len(object))
+	for key, value := range object {
+		if value == nil || value == "" {
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
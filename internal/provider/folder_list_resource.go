@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &folderListResource{}
+	_ list.ListResourceWithConfigure = &folderListResource{}
+)
+
+// NewFolderListResource returns a list resource that enumerates Passbolt folders for terraform query.
+func NewFolderListResource() list.ListResource {
+	return &folderListResource{}
+}
+
+type folderListResource struct {
+	client *tools.PassboltClient
+}
+
+type folderListResourceModel struct {
+	FolderPath types.String `tfsdk:"folder_path"`
+	Recursive  types.Bool   `tfsdk:"recursive"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+func (r *folderListResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	r.client = configureListResourceClient(req, resp)
+}
+
+func (r *folderListResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_folder"
+}
+
+func (r *folderListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists Passbolt folders, sorted by path. Parents are listed before their subfolders.",
+		Attributes: map[string]schema.Attribute{
+			"folder_path": schema.StringAttribute{
+				Optional: true,
+				Description: "Only list the subfolders of this folder. Accepts a unique folder name, a folder UUID, or an " +
+					"absolute path such as `/application_A/prod`. Leave unset to list every folder.",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "With `folder_path`, also list nested subfolders. Defaults to `false`.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list folders whose name starts with this prefix.",
+			},
+		},
+	}
+}
+
+func (r *folderListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	diags := ensureListLogin(ctx, r.client)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	var config folderListResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	folders, err := getCachedPassboltFolders(ctx, r.client)
+	if err != nil {
+		diags.AddError("Cannot get folders", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	items, err := folderListItems(folders, config)
+	if err != nil {
+		diags.AddError("Cannot list folders", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	stream.Results = streamListItems(ctx, req, items)
+}
+
+func folderListItems(folders []api.Folder, config folderListResourceModel) ([]listItem, error) {
+	pathsByID, err := buildFolderPathIndex(folders)
+	if err != nil {
+		return nil, err
+	}
+
+	var keep func(api.Folder) bool
+	if config.FolderPath.ValueString() != "" {
+		parentID, err := resolveFolderReferenceValue(folders, config.FolderPath.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		keep = func(folder api.Folder) bool { return folder.FolderParentID == parentID }
+		if config.Recursive.ValueBool() {
+			subtree := folderSubtreeIDs(folders, parentID)
+			keep = func(folder api.Folder) bool {
+				return folder.ID != parentID && slices.Contains(subtree, folder.ID)
+			}
+		}
+	}
+
+	items := make([]listItem, 0, len(folders))
+	for _, folder := range folders {
+		if keep != nil && !keep(folder) {
+			continue
+		}
+		if !listNameMatches(folder.Name, config.NamePrefix) {
+			continue
+		}
+
+		folderParent := types.StringNull()
+		if folder.FolderParentID != "" {
+			folderParent = types.StringValue(pathsByID[folder.FolderParentID])
+		}
+
+		items = append(items, listItem{
+			ID:          folder.ID,
+			DisplayName: pathsByID[folder.ID],
+			Attributes: map[string]any{
+				"name":          folder.Name,
+				"folder_parent": folderParent,
+			},
+		})
+	}
+
+	slices.SortFunc(items, func(a, b listItem) int {
+		return cmp.Or(cmp.Compare(a.DisplayName, b.DisplayName), cmp.Compare(a.ID, b.ID))
+	})
+
+	return items, nil
+}
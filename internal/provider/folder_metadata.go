@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ProtonMail/gopenpgp/v3/crypto"
@@ -76,6 +77,19 @@ func getPassboltFolders(
 	return folders, nil
 }
 
+// getCachedPassboltFolders returns every folder visible to the user. The list is memoized for the run and
+// invalidated whenever the provider creates, renames, moves, or deletes a folder.
+func getCachedPassboltFolders(ctx context.Context, client *tools.PassboltClient) ([]api.Folder, error) {
+	folders, err := tools.Memoize(ctx, client, tools.CacheFolders, func(ctx context.Context) ([]api.Folder, error) {
+		return getPassboltFolders(ctx, client, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(folders), nil
+}
+
 func getPassboltFolder(
 	ctx context.Context,
 	client *tools.PassboltClient,
@@ -195,6 +209,8 @@ func createPassboltFolder(
 	name string,
 	metadataType string,
 ) (api.Folder, string, error) {
+	defer client.Invalidate(tools.CacheFolders)
+
 	actualType := folderMetadataTypeForCreate(client, metadataType)
 	if err := validateFolderMetadataTypeCreation(client, actualType); err != nil {
 		return api.Folder{}, "", err
@@ -275,6 +291,8 @@ func updatePassboltFolder(
 	name string,
 	metadataType string,
 ) (string, error) {
+	defer client.Invalidate(tools.CacheFolders)
+
 	rawFolder, _, err := getPassboltFolder(ctx, client, folderID)
 	if err != nil {
 		return "", err
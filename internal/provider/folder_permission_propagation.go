@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+const (
+	folderPropagateNone      = "none"
+	folderPropagateChildren  = "children"
+	folderPropagateRecursive = "recursive"
+)
+
+// folderContents lists the subfolders and resources of a folder that a propagate mode reaches.
+type folderContents struct {
+	FolderIDs   []string
+	ResourceIDs []string
+}
+
+// collectFolderContents returns the contents of folderID that propagate reaches: nothing for none, the resources
+// and subfolders directly inside the folder for children, and the whole subtree for recursive.
+func collectFolderContents(foldersByID map[string]api.Folder, folderID string, propagate string) folderContents {
+	var contents folderContents
+	if propagate != folderPropagateChildren && propagate != folderPropagateRecursive {
+		return contents
+	}
+
+	visited := map[string]bool{folderID: true}
+	var walk func(id string)
+	walk = func(id string) {
+		folder := foldersByID[id]
+		for _, child := range folder.ChildrenResources {
+			contents.ResourceIDs = append(contents.ResourceIDs, child.ID)
+		}
+
+		for _, child := range folder.ChildrenFolders {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+
+			contents.FolderIDs = append(contents.FolderIDs, child.ID)
+			if propagate == folderPropagateRecursive {
+				walk(child.ID)
+			}
+		}
+	}
+	walk(folderID)
+
+	return contents
+}
+
+// permissionTypeFor returns the permission type target holds in permissions, or 0 when it has none.
+func permissionTypeFor(permissions []api.Permission, target permissionTarget) int {
+	for _, permission := range permissions {
+		if permission.ARO == target.ARO && permission.AROForeignKey == target.ID {
+			return permission.Type
+		}
+	}
+
+	return 0
+}
+
+// divergesFrom reports whether a content permission type differs from the folder's. Revoking with delete expects
+// target to hold no permission at all.
+func divergesFrom(permissionType int) func(int) bool {
+	want := permissionType
+	if want == -1 {
+		want = 0
+	}
+
+	return func(current int) bool { return current != want }
+}
+
+// narrowFolderContents returns the contents of folderID in reach of propagate but out of reach of narrower.
+func narrowFolderContents(
+	foldersByID map[string]api.Folder,
+	folderID string,
+	propagate string,
+	narrower string,
+) folderContents {
+	contents := collectFolderContents(foldersByID, folderID, propagate)
+	kept := collectFolderContents(foldersByID, folderID, narrower)
+
+	return folderContents{
+		FolderIDs:   withoutIDs(contents.FolderIDs, kept.FolderIDs),
+		ResourceIDs: withoutIDs(contents.ResourceIDs, kept.ResourceIDs),
+	}
+}
+
+func withoutIDs(ids []string, excluded []string) []string {
+	skip := make(map[string]bool, len(excluded))
+	for _, id := range excluded {
+		skip[id] = true
+	}
+
+	var remaining []string
+	for _, id := range ids {
+		if !skip[id] {
+			remaining = append(remaining, id)
+		}
+	}
+
+	return remaining
+}
+
+// folderContentsMatching returns the contents of folderID in reach of propagate but out of reach of narrower whose
+// permission type for target satisfies match. Resource permissions are fetched one resource at a time.
+func folderContentsMatching(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	folderID string,
+	propagate string,
+	narrower string,
+	target permissionTarget,
+	match func(permissionType int) bool,
+) (folderContents, error) {
+	var matching folderContents
+	if propagate != folderPropagateChildren && propagate != folderPropagateRecursive {
+		return matching, nil
+	}
+
+	folders, err := getPassboltFolders(ctx, client, &api.GetFoldersOptions{
+		ContainPermissions:       true,
+		ContainChildrenResources: true,
+		ContainChildrenFolders:   true,
+	})
+	if err != nil {
+		return matching, err
+	}
+
+	foldersByID := make(map[string]api.Folder, len(folders))
+	for _, folder := range folders {
+		foldersByID[folder.ID] = folder
+	}
+
+	contents := narrowFolderContents(foldersByID, folderID, propagate, narrower)
+	for _, id := range contents.FolderIDs {
+		if match(permissionTypeFor(foldersByID[id].Permissions, target)) {
+			matching.FolderIDs = append(matching.FolderIDs, id)
+		}
+	}
+
+	for _, id := range contents.ResourceIDs {
+		permissions, err := client.Client.GetResourcePermissions(ctx, id)
+		if err != nil {
+			return matching, fmt.Errorf("getting permissions of resource %s: %w", id, err)
+		}
+
+		if match(permissionTypeFor(permissions, target)) {
+			matching.ResourceIDs = append(matching.ResourceIDs, id)
+		}
+	}
+
+	return matching, nil
+}
+
+// shareFolderContents shares every folder and resource in contents with target.
+func shareFolderContents(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	contents folderContents,
+	target permissionTarget,
+	permissionType int,
+) error {
+	for _, id := range contents.FolderIDs {
+		if err := shareFolderPermission(ctx, client, id, target, permissionType); err != nil {
+			return fmt.Errorf("sharing folder %s: %w", id, err)
+		}
+	}
+
+	for _, id := range contents.ResourceIDs {
+		if err := sharePasswordPermission(ctx, client, id, target, permissionType); err != nil {
+			return fmt.Errorf("sharing resource %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// propagateFolderPermission shares the contents in reach of model's propagate mode whose permission for target
+// diverges from permissionType.
+func propagateFolderPermission(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	model folderPermissionModel,
+	target permissionTarget,
+	permissionType int,
+) error {
+	diverged, err := folderContentsMatching(
+		ctx,
+		client,
+		model.FolderID.ValueString(),
+		model.Propagate.ValueString(),
+		folderPropagateNone,
+		target,
+		divergesFrom(permissionType),
+	)
+	if err != nil {
+		return err
+	}
+
+	return shareFolderContents(ctx, client, diverged, target, permissionType)
+}
+
+// setDiverged records the contents whose permission differs from the folder's.
+func (m *folderPermissionModel) setDiverged(contents folderContents) {
+	m.DivergedFolderIDs = idSetValue(contents.FolderIDs)
+	m.DivergedResourceIDs = idSetValue(contents.ResourceIDs)
+}
+
+func idSetValue(ids []string) types.Set {
+	values := make([]types.String, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, types.StringValue(id))
+	}
+
+	return setStringValue(values)
+}
@@ -9,10 +9,15 @@ import (
 
 	"terraform-provider-passbolt/tools"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
@@ -20,9 +25,12 @@ import (
 
 // Ensure implementation
 var (
-	_ resource.Resource                = &folderPermissionResource{}
-	_ resource.ResourceWithConfigure   = &folderPermissionResource{}
-	_ resource.ResourceWithImportState = &folderPermissionResource{}
+	_ resource.Resource                     = &folderPermissionResource{}
+	_ resource.ResourceWithConfigure        = &folderPermissionResource{}
+	_ resource.ResourceWithConfigValidators = &folderPermissionResource{}
+	_ resource.ResourceWithImportState      = &folderPermissionResource{}
+	_ resource.ResourceWithIdentity         = &folderPermissionResource{}
+	_ resource.ResourceWithModifyPlan       = &folderPermissionResource{}
 )
 
 // NewFolderPermissionResource returns a Terraform resource for managing Passbolt folder permissions.
@@ -38,7 +46,12 @@ type folderPermissionModel struct {
 	ID         types.String `tfsdk:"id"`
 	FolderID   types.String `tfsdk:"folder_id"`
 	GroupName  types.String `tfsdk:"group_name"`
+	Username   types.String `tfsdk:"username"`
 	Permission types.String `tfsdk:"permission"` // "read", "update", "delete", "owner"
+	Propagate  types.String `tfsdk:"propagate"`  // "none", "children", "recursive"
+
+	DivergedFolderIDs   types.Set `tfsdk:"diverged_folder_ids"`
+	DivergedResourceIDs types.Set `tfsdk:"diverged_resource_ids"`
 }
 
 // Configure configures the folder permission resource.
@@ -67,20 +80,116 @@ func (r *folderPermissionResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	parts := strings.SplitN(req.ID, ":", 2)
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError("Invalid import ID format",
-			"Expected format: <folder_id>:<group_name>")
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	if req.ID == "" {
+		r.importStateFromIdentity(ctx, req, resp)
+
+		return
+	}
+
+	importID, err := parseFolderPermissionImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID format", err.Error())
+
+		return
+	}
+
+	folderID, diags := resolveFolderImportID(ctx, r.client, importID.Folder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := folderPermissionModel{
+		FolderID:   types.StringValue(folderID),
+		GroupName:  types.StringNull(),
+		Username:   types.StringNull(),
+		Permission: types.StringNull(),
+	}
+	if importID.Kind == passwordPermissionImportKindUser {
+		state.Username = types.StringValue(importID.Name)
+	} else {
+		state.GroupName = types.StringValue(importID.Name)
+	}
+
+	target, err := resolveFolderPermissionTarget(ctx, r.client, state, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+
+		return
+	}
+
+	r.importFolderPermission(ctx, state, target, resp)
+}
+
+func (r *folderPermissionResource) importStateFromIdentity(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	var identity folderPermissionIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := permissionTargetByID(ctx, r.client, identity.permissionPrincipalIdentity)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identity", err.Error())
+
+		return
+	}
+
+	state := folderPermissionModel{
+		FolderID:   identity.FolderID,
+		GroupName:  types.StringNull(),
+		Username:   types.StringNull(),
+		Permission: types.StringNull(),
+	}
+	r.importFolderPermission(ctx, state, target, resp)
+}
+
+// importFolderPermission adopts an existing grant. Importing fails when target has no permission on the folder, so a
+// typo cannot be imported and then planned as a new grant.
+func (r *folderPermissionResource) importFolderPermission(
+	ctx context.Context,
+	state folderPermissionModel,
+	target permissionTarget,
+	resp *resource.ImportStateResponse,
+) {
+	permission, err := readFolderPermission(ctx, r.client, state.FolderID.ValueString(), target)
+	if errors.Is(err, errFolderPermissionNotFound) {
+		resp.Diagnostics.AddError(
+			"Folder permission not found",
+			fmt.Sprintf("The %s %q has no permission on folder %s.", target.Kind, target.Name, state.FolderID.ValueString()),
+		)
+
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching folders", err.Error())
 
 		return
 	}
 
-	folderID := parts[0]
-	groupName := parts[1]
+	state.setTarget(target)
+	state.Permission = types.StringValue(permission)
+	state.Propagate = types.StringValue(folderPropagateNone)
+	state.setDiverged(folderContents{})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newFolderPermissionIdentity(state, target))...)
+}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("folder_id"), folderID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_name"), groupName)...)
+// IdentitySchema
+func (r *folderPermissionResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = permissionIdentitySchema("folder_id", "The UUID of the Passbolt folder.")
 }
 
 // Metadata for resource
@@ -92,6 +201,16 @@ func (r *folderPermissionResource) Metadata(
 	resp.TypeName = req.ProviderTypeName + "_folder_permission"
 }
 
+// ConfigValidators
+func (r *folderPermissionResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("group_name"),
+			path.MatchRoot("username"),
+		),
+	}
+}
+
 // Schema
 func (r *folderPermissionResource) Schema(
 	_ context.Context,
@@ -99,22 +218,39 @@ func (r *folderPermissionResource) Schema(
 	resp *resource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
-		Description: "Grants a Passbolt group permission to access a specific folder. " +
-			"This resource allows sharing a folder with a group with a defined level of access. " +
+		Description: "Grants a Passbolt group or user permission to access a specific folder. " +
+			"This resource allows sharing a folder with a group or an individual user with a defined level of access. " +
 			"To revoke access, simply remove the resource from your configuration.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
-				Description: "Internal resource ID, always in the format `folder_id:group_name`. " +
-					"Used to uniquely track the sharing link between a folder and a group.",
+				Description: "Internal resource ID in the format `folder_id:group_name` or `folder_id:user:username`. " +
+					"Used to uniquely track the sharing link between a folder and a group or user. The resource " +
+					"identity records the group or user UUID instead, so renaming the group or user in Passbolt does " +
+					"not orphan the permission.",
 			},
 			"folder_id": schema.StringAttribute{
 				Required:    true,
 				Description: "The UUID of the Passbolt folder to be shared. This folder must already exist.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"group_name": schema.StringAttribute{
-				Required:    true,
-				Description: "The name of the Passbolt group to grant access to. The group must already exist.",
+				Optional: true,
+				Description: "The name of the Passbolt group to grant access to. The group must already exist. " +
+					"Exactly one of `group_name` or `username` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Optional: true,
+				Description: "The exact username/email address of the Passbolt user to grant access to. " +
+					"Exactly one of `group_name` or `username` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"permission": schema.StringAttribute{
 				Required: true,
@@ -124,16 +260,59 @@ func (r *folderPermissionResource) Schema(
 					"	- `owner`: full control (admin rights)\n" +
 					"	- `delete`: used internally to revoke permissions (not typically used manually)",
 			},
+			"propagate": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(folderPropagateNone),
+				Description: "Whether the grant is also applied to the folder's contents. Must be one of `none`, " +
+					"`children`, or `recursive`. Defaults to `none`.\n" +
+					"	- `none`: only the folder is shared\n" +
+					"	- `children`: the passwords and subfolders directly inside the folder are shared too\n" +
+					"	- `recursive`: every password and subfolder below the folder is shared too",
+				Validators: []validator.String{
+					stringvalidator.OneOf(folderPropagateNone, folderPropagateChildren, folderPropagateRecursive),
+				},
+			},
+			"diverged_folder_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "UUIDs of the subfolders in reach of `propagate` where the group or user holds a " +
+					"different permission than on the folder. The next apply shares them again.",
+			},
+			"diverged_resource_ids": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "UUIDs of the passwords in reach of `propagate` where the group or user holds a " +
+					"different permission than on the folder. The next apply shares them again.",
+			},
 		},
 	}
 }
 
+// ModifyPlan plans no diverged contents, because every apply shares the contents in reach of propagate again.
+func (r *folderPermissionResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("diverged_folder_ids"), idSetValue(nil))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("diverged_resource_ids"), idSetValue(nil))...)
+}
+
 // Create
 func (r *folderPermissionResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan folderPermissionModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -141,9 +320,9 @@ func (r *folderPermissionResource) Create(
 		return
 	}
 
-	groupID, err := getgroupIDByName(ctx, r.client, plan.GroupName.ValueString())
+	target, err := resolveFolderPermissionTarget(ctx, r.client, plan, true)
 	if err != nil {
-		resp.Diagnostics.AddError("Group Not Found", err.Error())
+		resp.Diagnostics.AddError("Cannot resolve permission target", err.Error())
 
 		return
 	}
@@ -155,75 +334,97 @@ func (r *folderPermissionResource) Create(
 		return
 	}
 
-	err = helper.ShareFolderWithUsersAndGroups(
-		ctx,
-		r.client.Client,
-		plan.FolderID.ValueString(),
-		nil,
-		[]string{groupID},
-		permInt,
-	)
+	err = shareFolderPermission(ctx, r.client, plan.FolderID.ValueString(), target, permInt)
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot share folder (helper)", err.Error())
 
 		return
 	}
 
-	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.FolderID.ValueString(), plan.GroupName.ValueString()))
+	err = propagateFolderPermission(ctx, r.client, plan, target, permInt)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot share folder contents", err.Error())
+
+		return
+	}
+
+	plan.setDiverged(folderContents{})
+	plan.ID = types.StringValue(folderPermissionID(plan.FolderID.ValueString(), target.Kind, target.Name))
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newFolderPermissionIdentity(plan, target))...)
 }
 
 // Read - return state
 func (r *folderPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state folderPermissionModel
+	var identity folderPermissionIdentityModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(getIdentity(ctx, req.Identity, &identity)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	groupID, err := getgroupIDByName(ctx, r.client, state.GroupName.ValueString())
+	target, err := r.currentTarget(ctx, identity, state)
+	if errors.Is(err, errPermissionPrincipalNotFound) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error resolving group ID", err.Error())
+		resp.Diagnostics.AddError("Cannot resolve permission target", err.Error())
 
 		return
 	}
 
-	folders, err := getPassboltFolders(ctx, r.client, &api.GetFoldersOptions{
-		ContainPermissions: true,
-	})
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newFolderPermissionIdentity(state, target))...)
+
+	permission, err := readFolderPermission(ctx, r.client, state.FolderID.ValueString(), target)
+	if errors.Is(err, errFolderPermissionNotFound) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error fetching folders", err.Error())
 
 		return
 	}
 
-	for _, folder := range folders {
-		if folder.ID != state.FolderID.ValueString() {
-			continue
-		}
-
-		var latestPermType *int
+	if state.Propagate.IsNull() {
+		state.Propagate = types.StringValue(folderPropagateNone)
+	}
 
-		for _, perm := range folder.Permissions {
-			if perm.ARO == "Group" && perm.AROForeignKey == groupID {
-				latestPermType = &perm.Type
+	permInt, err := permissionStringToInt(permission)
+	if err != nil {
+		resp.Diagnostics.AddError("Permission Mapping Error", err.Error())
 
-				break
-			}
-		}
+		return
+	}
 
-		if latestPermType != nil {
-			state.Permission = types.StringValue(permissionIntToString(*latestPermType))
-			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-		} else {
-			resp.State.RemoveResource(ctx)
-		}
+	diverged, err := folderContentsMatching(
+		ctx,
+		r.client,
+		state.FolderID.ValueString(),
+		state.Propagate.ValueString(),
+		folderPropagateNone,
+		target,
+		divergesFrom(permInt),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read folder contents", err.Error())
 
 		return
 	}
 
-	resp.State.RemoveResource(ctx)
+	state.setTarget(target)
+	state.Permission = types.StringValue(permission)
+	state.setDiverged(diverged)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func permissionIntToString(perm int) string {
@@ -246,12 +447,28 @@ func (r *folderPermissionResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
-	var plan folderPermissionModel
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan, state folderPermissionModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	groupID, err := getgroupIDByName(ctx, r.client, plan.GroupName.ValueString())
+	target, err := resolveFolderPermissionTarget(ctx, r.client, plan, true)
 	if err != nil {
-		resp.Diagnostics.AddError("Group Not Found", err.Error())
+		resp.Diagnostics.AddError("Cannot resolve permission target", err.Error())
+
+		return
+	}
+
+	// Narrowing propagate leaves the contents now out of reach with the grant they received from the folder.
+	err = r.revokePropagated(ctx, state, plan.Propagate.ValueString(), target)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unshare folder contents", err.Error())
 
 		return
 	}
@@ -263,22 +480,33 @@ func (r *folderPermissionResource) Update(
 		return
 	}
 
-	err = helper.ShareFolderWithUsersAndGroups(
-		ctx,
-		r.client.Client,
-		plan.FolderID.ValueString(),
-		nil,
-		[]string{groupID},
-		permInt,
-	)
+	current, err := readFolderPermission(ctx, r.client, plan.FolderID.ValueString(), target)
+	if err != nil && !errors.Is(err, errFolderPermissionNotFound) {
+		resp.Diagnostics.AddError("Error fetching folders", err.Error())
+
+		return
+	}
+
+	if current != plan.Permission.ValueString() {
+		err = shareFolderPermission(ctx, r.client, plan.FolderID.ValueString(), target, permInt)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot update folder permission", err.Error())
+
+			return
+		}
+	}
+
+	err = propagateFolderPermission(ctx, r.client, plan, target, permInt)
 	if err != nil {
-		resp.Diagnostics.AddError("Cannot update folder permission", err.Error())
+		resp.Diagnostics.AddError("Cannot share folder contents", err.Error())
 
 		return
 	}
 
-	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.FolderID.ValueString(), plan.GroupName.ValueString()))
+	plan.setDiverged(folderContents{})
+	plan.ID = types.StringValue(folderPermissionID(plan.FolderID.ValueString(), target.Kind, target.Name))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newFolderPermissionIdentity(plan, target))...)
 }
 
 // Delete (revoke sharing)
@@ -287,28 +515,35 @@ func (r *folderPermissionResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state folderPermissionModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	var identity folderPermissionIdentityModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(getIdentity(ctx, req.Identity, &identity)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	groupID, err := getgroupIDByName(ctx, r.client, state.GroupName.ValueString())
+	target, err := r.currentTarget(ctx, identity, state)
+	if errors.Is(err, errPermissionPrincipalNotFound) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Group Not Found In Delete", err.Error())
+		resp.Diagnostics.AddError("Cannot resolve permission target", err.Error())
+
+		return
+	}
+	if err := r.revokePropagated(ctx, state, folderPropagateNone, target); err != nil {
+		resp.Diagnostics.AddError("Failed to unshare folder contents", err.Error())
 
 		return
 	}
+
 	// ShareFolderWithUsersAndGroups with perm=-1 – remove all permissions
-	err = helper.ShareFolderWithUsersAndGroups(
-		ctx,
-		r.client.Client,
-		state.FolderID.ValueString(),
-		nil,
-		[]string{groupID},
-		-1,
-	)
+	err = shareFolderPermission(ctx, r.client, state.FolderID.ValueString(), target, -1)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to unshare folder", err.Error())
 
@@ -318,13 +553,172 @@ func (r *folderPermissionResource) Delete(
 
 // ----------------- Helpers ------------------
 
+// revokePropagated revokes target from the contents in reach of the state's propagate but out of reach of narrower
+// where it still holds the state's permission. Contents where the permission was changed outside Terraform are left
+// alone.
+func (r *folderPermissionResource) revokePropagated(
+	ctx context.Context,
+	state folderPermissionModel,
+	narrower string,
+	target permissionTarget,
+) error {
+	permInt, err := permissionStringToInt(state.Permission.ValueString())
+	if err != nil || permInt == -1 {
+		return err
+	}
+
+	propagated, err := folderContentsMatching(
+		ctx,
+		r.client,
+		state.FolderID.ValueString(),
+		state.Propagate.ValueString(),
+		narrower,
+		target,
+		func(current int) bool { return current == permInt },
+	)
+	if err != nil {
+		return err
+	}
+
+	return shareFolderContents(ctx, r.client, propagated, target, -1)
+}
+
+// currentTarget resolves the group of a permission that is already in state.
+func (r *folderPermissionResource) currentTarget(
+	ctx context.Context,
+	identity folderPermissionIdentityModel,
+	state folderPermissionModel,
+) (permissionTarget, error) {
+	return currentPermissionTarget(ctx, r.client, identity.permissionPrincipalIdentity, func() (permissionTarget, error) {
+		return resolveFolderPermissionTarget(ctx, r.client, state, false)
+	})
+}
+
+func resolveFolderPermissionTarget(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	model folderPermissionModel,
+	requireActiveUser bool,
+) (permissionTarget, error) {
+	return resolvePermissionTarget(ctx, client, model.GroupName, model.Username, requireActiveUser)
+}
+
+// readFolderPermission returns the permission target holds on the folder, or errFolderPermissionNotFound when the
+// folder does not exist or target has no permission on it.
+func readFolderPermission(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	folderID string,
+	target permissionTarget,
+) (string, error) {
+	folders, err := getPassboltFolders(ctx, client, &api.GetFoldersOptions{
+		ContainPermissions: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, folder := range folders {
+		if folder.ID != folderID {
+			continue
+		}
+
+		for _, perm := range folder.Permissions {
+			if perm.ARO == target.ARO && perm.AROForeignKey == target.ID {
+				return permissionIntToString(perm.Type), nil
+			}
+		}
+
+		break
+	}
+
+	return "", errFolderPermissionNotFound
+}
+
+func shareFolderPermission(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	folderID string,
+	target permissionTarget,
+	permissionType int,
+) error {
+	users, groups := target.shareLists()
+
+	return helper.ShareFolderWithUsersAndGroups(ctx, client.Client, folderID, users, groups, permissionType)
+}
+
+// setTarget points the model at target, following a rename of the group or user in Passbolt.
+func (m *folderPermissionModel) setTarget(target permissionTarget) {
+	name := &m.GroupName
+	if target.Kind == passwordPermissionImportKindUser {
+		name = &m.Username
+	}
+	if !target.matchesName(name.ValueString()) {
+		*name = types.StringValue(target.Name)
+	}
+
+	m.ID = types.StringValue(folderPermissionID(m.FolderID.ValueString(), target.Kind, name.ValueString()))
+}
+
+// folderPermissionID keeps the original `folder_id:group_name` format for groups.
+func folderPermissionID(folderID string, kind string, name string) string {
+	if kind == passwordPermissionImportKindUser {
+		return fmt.Sprintf("%s:%s:%s", folderID, kind, name)
+	}
+
+	return fmt.Sprintf("%s:%s", folderID, name)
+}
+
+type folderPermissionImportID struct {
+	// Folder is a folder UUID, an absolute folder path, or a unique folder name.
+	Folder string
+	Kind   string
+	Name   string
+}
+
+// parseFolderPermissionImportID accepts `folder:user:username`, `folder:group:group_name`, and the original
+// `folder_id:group_name`, where folder is a UUID or an absolute path such as `/application_A/prod`.
+func parseFolderPermissionImportID(value string) (folderPermissionImportID, error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) < 2 {
+		return folderPermissionImportID{}, errors.New(
+			"expected format: <folder_id>:group:<group_name>, <folder_id>:user:<username>, or " +
+				"</folder/path>:group:<group_name>",
+		)
+	}
+
+	importID := folderPermissionImportID{
+		Folder: parts[0],
+		Kind:   passwordPermissionImportKindGroup,
+		Name:   strings.Join(parts[1:], ":"),
+	}
+	if len(parts) == 3 && (parts[1] == passwordPermissionImportKindGroup || parts[1] == passwordPermissionImportKindUser) {
+		importID.Kind = parts[1]
+		importID.Name = parts[2]
+	}
+
+	if importID.Folder == "" || importID.Name == "" {
+		return folderPermissionImportID{}, errors.New("folder and target name must not be empty")
+	}
+
+	return importID, nil
+}
+
+func newFolderPermissionIdentity(model folderPermissionModel, target permissionTarget) folderPermissionIdentityModel {
+	return folderPermissionIdentityModel{
+		FolderID:                    model.FolderID,
+		permissionPrincipalIdentity: newPermissionPrincipalIdentity(target),
+	}
+}
+
 var (
-	errGroupNotFound     = errors.New("group not found")
-	errInvalidPermission = errors.New("invalid permission")
+	errFolderPermissionNotFound = errors.New("folder permission not found")
+	errGroupNotFound            = errors.New("group not found")
+	errInvalidPermission        = errors.New("invalid permission")
 )
 
 func getgroupIDByName(ctx context.Context, client *tools.PassboltClient, groupName string) (string, error) {
-	groups, err := client.Client.GetGroups(ctx, nil)
+	groups, err := client.Groups(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get groups: %w", err)
 	}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestParseFolderPermissionImportID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		want  folderPermissionImportID
+	}{
+		"legacy group": {
+			value: "folder-id:Developers",
+			want:  folderPermissionImportID{Folder: "folder-id", Kind: passwordPermissionImportKindGroup, Name: "Developers"},
+		},
+		"legacy group name with colon": {
+			value: "folder-id:Dev:Ops",
+			want:  folderPermissionImportID{Folder: "folder-id", Kind: passwordPermissionImportKindGroup, Name: "Dev:Ops"},
+		},
+		"group": {
+			value: "folder-id:group:Developers",
+			want:  folderPermissionImportID{Folder: "folder-id", Kind: passwordPermissionImportKindGroup, Name: "Developers"},
+		},
+		"folder path": {
+			value: "/application_A/prod:group:Developers",
+			want: folderPermissionImportID{
+				Folder: "/application_A/prod",
+				Kind:   passwordPermissionImportKindGroup,
+				Name:   "Developers",
+			},
+		},
+		"user": {
+			value: "folder-id:user:contractor@example.com",
+			want: folderPermissionImportID{
+				Folder: "folder-id",
+				Kind:   passwordPermissionImportKindUser,
+				Name:   "contractor@example.com",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseFolderPermissionImportID(test.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("expected %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseFolderPermissionImportIDRejectsInvalidValues(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "folder-id", ":Developers", "folder-id:", "folder-id:user:"} {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := parseFolderPermissionImportID(value); err == nil {
+				t.Fatalf("expected error for import ID %q", value)
+			}
+		})
+	}
+}
+
+func TestFolderPermissionModelSetTarget(t *testing.T) {
+	t.Parallel()
+
+	group := folderPermissionModel{
+		FolderID:  types.StringValue("folder-id"),
+		GroupName: types.StringValue("Developers"),
+		Username:  types.StringNull(),
+	}
+	group.setTarget(permissionTarget{Kind: passwordPermissionImportKindGroup, ID: "group-id", Name: "Engineering"})
+	if group.GroupName.ValueString() != "Engineering" || group.ID.ValueString() != "folder-id:Engineering" {
+		t.Fatalf("expected the renamed group, got %s and %s", group.GroupName, group.ID)
+	}
+
+	user := folderPermissionModel{
+		FolderID:  types.StringValue("folder-id"),
+		GroupName: types.StringNull(),
+		Username:  types.StringValue("Contractor@Example.com"),
+	}
+	user.setTarget(permissionTarget{Kind: passwordPermissionImportKindUser, ID: "user-id", Name: "contractor@example.com"})
+	if user.Username.ValueString() != "Contractor@Example.com" || !user.GroupName.IsNull() {
+		t.Fatalf("expected the configured username to be kept, got %s", user.Username)
+	}
+	if want := "folder-id:user:Contractor@Example.com"; user.ID.ValueString() != want {
+		t.Fatalf("expected id %q, got %q", want, user.ID.ValueString())
+	}
+}
+
+func TestFolderPermissionTargetAttributesRequireReplace(t *testing.T) {
+	t.Parallel()
+
+	var resp resource.SchemaResponse
+	NewFolderPermissionResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+	for _, name := range []string{"folder_id", "group_name", "username"} {
+		stringAttr, ok := resp.Schema.Attributes[name].(schema.StringAttribute)
+		if !ok {
+			t.Fatalf("expected %s to be a string attribute", name)
+		}
+		if len(stringAttr.PlanModifiers) == 0 {
+			t.Fatalf("expected %s to require replacement", name)
+		}
+	}
+}
+
+func TestCollectFolderContents(t *testing.T) {
+	t.Parallel()
+
+	foldersByID := testFolderTree()
+
+	tests := map[string]struct {
+		propagate string
+		want      folderContents
+	}{
+		"none": {
+			propagate: folderPropagateNone,
+		},
+		"children": {
+			propagate: folderPropagateChildren,
+			want:      folderContents{FolderIDs: []string{"db", "web"}, ResourceIDs: []string{"api-key"}},
+		},
+		"recursive": {
+			propagate: folderPropagateRecursive,
+			want: folderContents{
+				FolderIDs:   []string{"db", "replicas", "web"},
+				ResourceIDs: []string{"api-key", "db-admin", "replica-admin"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := collectFolderContents(foldersByID, "prod", test.propagate)
+			if !slices.Equal(got.FolderIDs, test.want.FolderIDs) || !slices.Equal(got.ResourceIDs, test.want.ResourceIDs) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestNarrowFolderContents(t *testing.T) {
+	t.Parallel()
+
+	foldersByID := testFolderTree()
+
+	tests := map[string]struct {
+		propagate string
+		narrower  string
+		want      folderContents
+	}{
+		"recursive to none": {
+			propagate: folderPropagateRecursive,
+			narrower:  folderPropagateNone,
+			want: folderContents{
+				FolderIDs:   []string{"db", "replicas", "web"},
+				ResourceIDs: []string{"api-key", "db-admin", "replica-admin"},
+			},
+		},
+		"recursive to children": {
+			propagate: folderPropagateRecursive,
+			narrower:  folderPropagateChildren,
+			want:      folderContents{FolderIDs: []string{"replicas"}, ResourceIDs: []string{"db-admin", "replica-admin"}},
+		},
+		"children to none": {
+			propagate: folderPropagateChildren,
+			narrower:  folderPropagateNone,
+			want:      folderContents{FolderIDs: []string{"db", "web"}, ResourceIDs: []string{"api-key"}},
+		},
+		"unchanged": {
+			propagate: folderPropagateRecursive,
+			narrower:  folderPropagateRecursive,
+		},
+		"widened": {
+			propagate: folderPropagateChildren,
+			narrower:  folderPropagateRecursive,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := narrowFolderContents(foldersByID, "prod", test.propagate, test.narrower)
+			if !slices.Equal(got.FolderIDs, test.want.FolderIDs) || !slices.Equal(got.ResourceIDs, test.want.ResourceIDs) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDivergesFrom(t *testing.T) {
+	t.Parallel()
+
+	permissions := []api.Permission{
+		{ARO: passwordPermissionAROGroup, AROForeignKey: "developers", Type: 7},
+		{ARO: passwordPermissionAROUser, AROForeignKey: "developers", Type: 1},
+	}
+	group := permissionTarget{ARO: passwordPermissionAROGroup, ID: "developers"}
+	missing := permissionTarget{ARO: passwordPermissionAROGroup, ID: "auditors"}
+
+	if divergesFrom(7)(permissionTypeFor(permissions, group)) {
+		t.Fatal("expected the same permission not to diverge")
+	}
+	if !divergesFrom(15)(permissionTypeFor(permissions, group)) {
+		t.Fatal("expected a different permission to diverge")
+	}
+	if !divergesFrom(1)(permissionTypeFor(permissions, missing)) {
+		t.Fatal("expected a missing permission to diverge")
+	}
+	if divergesFrom(-1)(permissionTypeFor(permissions, missing)) {
+		t.Fatal("expected a missing permission not to diverge from delete")
+	}
+}
+
+func testFolderTree() map[string]api.Folder {
+	return map[string]api.Folder{
+		"prod": {
+			ID:                "prod",
+			ChildrenResources: []api.Resource{{ID: "api-key"}},
+			ChildrenFolders:   []api.Folder{{ID: "db"}, {ID: "web"}},
+		},
+		"db": {
+			ID:                "db",
+			ChildrenResources: []api.Resource{{ID: "db-admin"}},
+			ChildrenFolders:   []api.Folder{{ID: "replicas"}},
+		},
+		"replicas": {ID: "replicas", ChildrenResources: []api.Resource{{ID: "replica-admin"}}},
+		"web":      {ID: "web"},
+	}
+}
@@ -1,11 +1,14 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/passbolt/go-passbolt/api"
 )
 
 func TestAccFolderPermissionResource_basic(t *testing.T) {
@@ -27,6 +30,121 @@ func TestAccFolderPermissionResource_basic(t *testing.T) {
 			testStepCreatePermissionWithGroup(baseURL, privateKey, passphrase, managerID, groupName, folderName, "read"),
 			testStepNoDriftPermissionWithGroup(baseURL, privateKey, passphrase, managerID, groupName, folderName, "read"),
 			testStepUpdatePermissionWithGroup(baseURL, privateKey, passphrase, managerID, groupName, folderName, "owner"),
+			{
+				ResourceName:      "passbolt_folder_permission.perm",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "passbolt_folder_permission.perm",
+				ImportState:       true,
+				ImportStateId:     "/" + folderName + ":group:" + groupName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFolderPermissionResource_user(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(
+		t,
+		"PASSBOLT_BASE_URL",
+		"PASSBOLT_PRIVATE_KEY",
+		"PASSBOLT_PASSPHRASE",
+		"PASSBOLT_TEST_USER_EMAIL",
+	)
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	username := os.Getenv("PASSBOLT_TEST_USER_EMAIL")
+	folderName := testAccName("shared-folder-user", testAccSuffix())
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPermissionWithUserConfig(baseURL, privateKey, passphrase, username, folderName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "username", username),
+					resource.TestCheckNoResourceAttr("passbolt_folder_permission.perm", "group_name"),
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "permission", "read"),
+				),
+			},
+			{
+				Config: testPermissionWithUserConfig(baseURL, privateKey, passphrase, username, folderName, "update"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "permission", "update"),
+				),
+			},
+			{
+				ResourceName:      "passbolt_folder_permission.perm",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFolderPermissionResource_propagate(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(
+		t,
+		"PASSBOLT_BASE_URL",
+		"PASSBOLT_PRIVATE_KEY",
+		"PASSBOLT_PASSPHRASE",
+		"PASSBOLT_TEST_USER_EMAIL",
+	)
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	username := os.Getenv("PASSBOLT_TEST_USER_EMAIL")
+	folderName := testAccName("shared-folder-propagate", testAccSuffix())
+	userID := testAccPasswordPermissionUserID(t, baseURL, privateKey, passphrase, username)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPermissionPropagateConfig(baseURL, privateKey, passphrase, username, folderName, "children"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "propagate", "children"),
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "diverged_folder_ids.#", "0"),
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "diverged_resource_ids.#", "0"),
+				),
+			},
+			{
+				Config: testPermissionPropagateConfig(baseURL, privateKey, passphrase, username, folderName, "recursive"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "propagate", "recursive"),
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "diverged_folder_ids.#", "0"),
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "diverged_resource_ids.#", "0"),
+					testCheckPasswordPermissionType(
+						baseURL,
+						privateKey,
+						passphrase,
+						"passbolt_password.nested",
+						userID,
+						"User",
+						1,
+					),
+				),
+			},
+			{
+				Config:   testPermissionPropagateConfig(baseURL, privateKey, passphrase, username, folderName, "recursive"),
+				PlanOnly: true,
+			},
+			{
+				Config: testPermissionPropagateConfig(baseURL, privateKey, passphrase, username, folderName, "none"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_folder_permission.perm", "propagate", "none"),
+					testCheckNoPasswordPermission(baseURL, privateKey, passphrase, "passbolt_password.nested", userID),
+				),
+			},
 		},
 	})
 }
@@ -115,3 +233,116 @@ resource "passbolt_folder_permission" "perm" {
 }
 `, baseURL, privateKey, passphrase, groupName, managerID, folderName, permission)
 }
+
+func testPermissionWithUserConfig(
+	baseURL,
+	privateKey,
+	passphrase,
+	username,
+	folderName,
+	permission string,
+) string {
+	return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_folder" "shared" {
+  name = "%s"
+}
+
+resource "passbolt_folder_permission" "perm" {
+  folder_id  = passbolt_folder.shared.id
+  username   = "%s"
+  permission = "%s"
+}
+`, baseURL, privateKey, passphrase, folderName, username, permission)
+}
+
+func testPermissionPropagateConfig(
+	baseURL,
+	privateKey,
+	passphrase,
+	username,
+	folderName,
+	propagate string,
+) string {
+	return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_folder" "shared" {
+  name = "%s"
+}
+
+resource "passbolt_folder" "child" {
+  name          = "child"
+  folder_parent = passbolt_folder.shared.id
+}
+
+resource "passbolt_password" "nested" {
+  name          = "nested"
+  username      = "propagate-user"
+  password      = "propagate-secret"
+  folder_parent = passbolt_folder.child.id
+}
+
+resource "passbolt_folder_permission" "perm" {
+  folder_id  = passbolt_folder.shared.id
+  username   = "%s"
+  permission = "read"
+  propagate  = "%s"
+
+  depends_on = [passbolt_password.nested]
+}
+`, baseURL, privateKey, passphrase, folderName, username, propagate)
+}
+
+func testCheckNoPasswordPermission(
+	baseURL,
+	privateKey,
+	passphrase,
+	passwordResourceAddress,
+	targetID string,
+) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		password, ok := state.RootModule().Resources[passwordResourceAddress]
+		if !ok {
+			return fmt.Errorf("%s not found in Terraform state", passwordResourceAddress)
+		}
+
+		ctx := context.Background()
+		client, err := api.NewClient(nil, "", baseURL, privateKey, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to create Passbolt API client: %w", err)
+		}
+		if err := client.Login(ctx); err != nil {
+			return fmt.Errorf("failed to log in to Passbolt API: %w", err)
+		}
+		defer func() {
+			_ = client.Logout(ctx)
+		}()
+
+		permissions, err := client.GetResourcePermissions(ctx, password.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get resource permissions: %w", err)
+		}
+
+		for _, permission := range permissions {
+			if permission.AROForeignKey == targetID {
+				return fmt.Errorf("expected no permission for %s on resource %s", targetID, password.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
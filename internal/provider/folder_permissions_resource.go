@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure implementation
+var (
+	_ resource.Resource                   = &folderPermissionsResource{}
+	_ resource.ResourceWithConfigure      = &folderPermissionsResource{}
+	_ resource.ResourceWithValidateConfig = &folderPermissionsResource{}
+	_ resource.ResourceWithImportState    = &folderPermissionsResource{}
+	_ resource.ResourceWithIdentity       = &folderPermissionsResource{}
+)
+
+var errFolderNotFound = errors.New("folder not found")
+
+// NewFolderPermissionsResource returns a Terraform resource that manages every permission of a Passbolt folder.
+func NewFolderPermissionsResource() resource.Resource {
+	return &folderPermissionsResource{}
+}
+
+type folderPermissionsResource struct {
+	client *tools.PassboltClient
+}
+
+type folderPermissionsModel struct {
+	ID          types.String           `tfsdk:"id"`
+	FolderID    types.String           `tfsdk:"folder_id"`
+	Permissions []permissionEntryModel `tfsdk:"permissions"`
+}
+
+func (r *folderPermissionsResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltClient, got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *folderPermissionsResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_folder_permissions"
+}
+
+func (r *folderPermissionsResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete list of groups and users with access to a Passbolt folder. Grants that " +
+			"are not in `permissions`, including grants added in the Passbolt UI, are revoked on apply and show up " +
+			"as drift until then. Do not combine it with `passbolt_folder_permission` on the same folder.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID of the folder.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"folder_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the Passbolt folder whose permissions are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": permissionEntriesAttribute("folder"),
+		},
+	}
+}
+
+func (r *folderPermissionsResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	resp.Diagnostics.Append(validatePermissionEntriesConfig(ctx, req.Config)...)
+}
+
+func (r *folderPermissionsResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the folder.")
+}
+
+func (r *folderPermissionsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	importByIDOrIdentity(ctx, req, resp, func(id string) (string, diag.Diagnostics) {
+		return resolveFolderImportID(ctx, r.client, id)
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var folderID types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &folderID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("folder_id"), folderID)...)
+}
+
+func (r *folderPermissionsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan folderPermissionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyFolderPermissions(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("Cannot share folder", err.Error())
+
+		return
+	}
+
+	plan.ID = plan.FolderID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *folderPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state folderPermissionsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
+	permissions, err := getFolderPermissions(ctx, r.client, state.FolderID.ValueString())
+	if errors.Is(err, errFolderNotFound) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching folders", err.Error())
+
+		return
+	}
+
+	entries, err := readPermissionEntries(ctx, r.client, permissions, state.Permissions)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read folder permissions", err.Error())
+
+		return
+	}
+
+	state.ID = state.FolderID
+	state.Permissions = entries
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *folderPermissionsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan folderPermissionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyFolderPermissions(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("Cannot update folder permissions", err.Error())
+
+		return
+	}
+
+	plan.ID = plan.FolderID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+// Delete stops managing the folder's permissions. Revoking every grant would leave the folder without an owner, so
+// the permissions are left as they are.
+func (r *folderPermissionsResource) Delete(
+	_ context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+}
+
+// applyFolderPermissions makes the folder's permissions match plan in one share request.
+func (r *folderPermissionsResource) applyFolderPermissions(ctx context.Context, plan folderPermissionsModel) error {
+	desired, err := resolveDesiredPermissions(ctx, r.client, plan.Permissions)
+	if err != nil {
+		return err
+	}
+
+	current, err := getFolderPermissions(ctx, r.client, plan.FolderID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	changes, err := permissionChanges(current, desired)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return helper.ShareFolder(ctx, r.client.Client, plan.FolderID.ValueString(), changes)
+}
+
+// getFolderPermissions returns every permission on the folder, or errFolderNotFound.
+func getFolderPermissions(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	folderID string,
+) ([]api.Permission, error) {
+	folders, err := getPassboltFolders(ctx, client, &api.GetFoldersOptions{
+		ContainPermissions: true,
+		FilterHasID:        []string{folderID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, folder := range folders {
+		if folder.ID == folderID {
+			return folder.Permissions, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errFolderNotFound, folderID)
+}
@@ -0,0 +1,125 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFolderPermissionsResource_basic(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(
+		t,
+		"PASSBOLT_BASE_URL",
+		"PASSBOLT_PRIVATE_KEY",
+		"PASSBOLT_PASSPHRASE",
+		"PASSBOLT_MANAGER_ID",
+		"PASSBOLT_TEST_USER_EMAIL",
+	)
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	managerID := os.Getenv("PASSBOLT_MANAGER_ID")
+	username := os.Getenv("PASSBOLT_TEST_USER_EMAIL")
+	suffix := testAccSuffix()
+	groupName := testAccName("acl-owners", suffix)
+	folderName := testAccName("acl-folder", suffix)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFolderPermissionsConfig(
+					baseURL, privateKey, passphrase, managerID, groupName, folderName, username, "read",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"passbolt_folder_permissions.acl", "id",
+						"passbolt_folder.shared", "id",
+					),
+					resource.TestCheckResourceAttr("passbolt_folder_permissions.acl", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("passbolt_folder_permissions.acl", "permissions.*", map[string]string{
+						"group_name": groupName,
+						"permission": "owner",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("passbolt_folder_permissions.acl", "permissions.*", map[string]string{
+						"username":   username,
+						"permission": "read",
+					}),
+				),
+			},
+			{
+				Config: testFolderPermissionsConfig(
+					baseURL, privateKey, passphrase, managerID, groupName, folderName, username, "update",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_folder_permissions.acl", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("passbolt_folder_permissions.acl", "permissions.*", map[string]string{
+						"username":   username,
+						"permission": "update",
+					}),
+				),
+			},
+			{
+				ResourceName:      "passbolt_folder_permissions.acl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "passbolt_folder_permissions.acl",
+				ImportState:       true,
+				ImportStateId:     "/" + folderName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testFolderPermissionsConfig(
+	baseURL,
+	privateKey,
+	passphrase,
+	managerID,
+	groupName,
+	folderName,
+	username,
+	permission string,
+) string {
+	return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_group" "owners" {
+  name     = "%s"
+  managers = ["%s"]
+}
+
+resource "passbolt_folder" "shared" {
+  name = "%s"
+}
+
+resource "passbolt_folder_permissions" "acl" {
+  folder_id = passbolt_folder.shared.id
+
+  permissions = [
+    {
+      group_name = passbolt_group.owners.name
+      permission = "owner"
+    },
+    {
+      username   = "%s"
+      permission = "%s"
+    },
+  ]
+}
+`, baseURL, privateKey, passphrase, groupName, managerID, folderName, username, permission)
+}
@@ -34,7 +34,7 @@ func resolveFolderReference(
 		return "", diags
 	}
 
-	folders, err := getPassboltFolders(ctx, client, nil)
+	folders, err := getCachedPassboltFolders(ctx, client)
 	if err != nil {
 		diags.AddError("Cannot get folders", err.Error())
 
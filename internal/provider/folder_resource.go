@@ -7,7 +7,7 @@ import (
 	"terraform-provider-passbolt/tools"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -20,6 +20,7 @@ var (
 	_ resource.Resource                = &folderResource{}
 	_ resource.ResourceWithConfigure   = &folderResource{}
 	_ resource.ResourceWithImportState = &folderResource{}
+	_ resource.ResourceWithIdentity    = &folderResource{}
 )
 
 // NewFolderResource returns interface a new instance of folderResource that implements the resource.Resource interface.
@@ -70,7 +71,21 @@ func (r *folderResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	importByIDOrIdentity(ctx, req, resp, func(id string) (string, diag.Diagnostics) {
+		return resolveFolderImportID(ctx, r.client, id)
+	})
+}
+
+func (r *folderResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the Passbolt folder.")
 }
 
 // Metadata returns the resource type name.
@@ -131,6 +146,10 @@ func (r *folderResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 
 // Create a new resource.
 func (r *folderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Info(ctx, "Create folder resource")
 
 	var plan foldersModelCreate
@@ -176,10 +195,15 @@ func (r *folderResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Info(ctx, "Read folder resource")
 	var state foldersModelCreate
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -187,6 +211,8 @@ func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
 	rawFolder, currentFolder, err := getPassboltFolder(ctx, r.client, state.ID.ValueString())
 	if err != nil {
 		if isNotFoundError(err) {
@@ -199,7 +225,7 @@ func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	folders, err := getPassboltFolders(ctx, r.client, nil)
+	folders, err := getCachedPassboltFolders(ctx, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot get folders", err.Error())
 
@@ -225,6 +251,10 @@ func (r *folderResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Info(ctx, "Update folder resource: starting")
 
 	var plan foldersModelCreate
@@ -284,9 +314,14 @@ func (r *folderResource) Update(ctx context.Context, req resource.UpdateRequest,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *folderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state foldersModelCreate
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -294,6 +329,7 @@ func (r *folderResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	err := r.client.Client.DeleteFolder(ctx, state.ID.ValueString())
+	r.client.Invalidate(tools.CacheFolders)
 	if err != nil {
 		if !isNotFoundError(err) {
 			resp.Diagnostics.AddError("Error deleting folder", err.Error())
@@ -319,7 +355,9 @@ func (r *folderResource) moveFolderIfNeeded(
 		return true
 	}
 
-	if err := r.client.Client.MoveFolder(ctx, state.ID.ValueString(), desiredParentID); err != nil {
+	err := r.client.Client.MoveFolder(ctx, state.ID.ValueString(), desiredParentID)
+	r.client.Invalidate(tools.CacheFolders)
+	if err != nil {
 		tflog.Error(ctx, "Update: API move failed", map[string]any{
 			"error": err.Error(),
 		})
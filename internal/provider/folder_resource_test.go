@@ -26,6 +26,7 @@ func TestAccFolderResource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			testStepCreateFolder(baseURL, privateKey, passphrase, initialName),
 			testStepCheckFolderNoDrift(baseURL, privateKey, passphrase, initialName),
+			testStepImportFolderByPath("/" + initialName),
 			testStepUpdateFolderName(baseURL, privateKey, passphrase, updatedName),
 		},
 	})
@@ -72,6 +73,15 @@ func TestAccFolderResource_parentReferenceVariants(t *testing.T) {
 	})
 }
 
+func testStepImportFolderByPath(folderPath string) resource.TestStep {
+	return resource.TestStep{
+		ResourceName:      "passbolt_folder.example",
+		ImportState:       true,
+		ImportStateId:     folderPath,
+		ImportStateVerify: true,
+	}
+}
+
 func testStepCreateFolder(baseURL, privateKey, passphrase, name string) resource.TestStep {
 	return resource.TestStep{
 		Config: testFolderConfig(baseURL, privateKey, passphrase, name),
@@ -133,9 +133,13 @@ func (d *foldersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 
 // Read refreshes the Terraform state with the latest data.
 func (d *foldersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !ensureLogin(ctx, d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state foldersDataSourceModel
 
-	folders, err := getPassboltFolders(ctx, d.client, nil)
+	folders, err := getCachedPassboltFolders(ctx, d.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read folders", "",
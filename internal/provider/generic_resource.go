@@ -0,0 +1,473 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &genericResource{}
+	_ resource.ResourceWithConfigure   = &genericResource{}
+	_ resource.ResourceWithImportState = &genericResource{}
+	_ resource.ResourceWithIdentity    = &genericResource{}
+	_ resource.ResourceWithModifyPlan  = &genericResource{}
+)
+
+// NewGenericResource returns a resource that manages Passbolt resources of any resource type.
+func NewGenericResource() resource.Resource {
+	return &genericResource{}
+}
+
+// genericResource manages a Passbolt resource whose metadata and secret are given as JSON-like values and checked
+// against the JSON schemas of its resource type.
+type genericResource struct {
+	client *tools.PassboltClient
+}
+
+type genericResourceModel struct {
+	ID                types.String  `tfsdk:"id"`
+	ResourceType      types.String  `tfsdk:"resource_type"`
+	FolderParent      types.String  `tfsdk:"folder_parent"`
+	Metadata          types.Dynamic `tfsdk:"metadata"`
+	SecretJSON        types.String  `tfsdk:"secret_json"`
+	SecretJSONVersion types.Int64   `tfsdk:"secret_json_version"`
+}
+
+// Fields the provider adds to v5 metadata and secrets itself.
+var genericResourceManagedFields = []string{"object_type", "resource_type_id"}
+
+func (r *genericResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltClient, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *genericResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource"
+}
+
+func (r *genericResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Passbolt resource of any resource type, such as standalone TOTP or custom fields. " +
+			"`metadata` and `secret_json` are validated against the JSON schemas of the resource type " +
+			"while planning.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID of the Passbolt resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_type": schema.StringAttribute{
+				Required: true,
+				Description: "Slug of the Passbolt resource type, for example `v5-default`, `v5-totp-standalone`, or " +
+					"`v5-custom-fields`. Changing it replaces the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"folder_parent": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name or UUID of an existing folder to place the resource in. Leave unset to place at top level.",
+			},
+			"metadata": schema.DynamicAttribute{
+				Required: true,
+				Description: "Metadata object of the resource, such as `name`, `username`, `uris`, or `custom_fields`, " +
+					"in the shape of the resource type schema. Encrypted for v5 resource types. Keys removed from the " +
+					"configuration are removed in Passbolt.",
+			},
+			"secret_json": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Description: "Secret of the resource as a JSON object in the shape of the resource type schema, for " +
+					"example `jsonencode({ password = \"...\" })`. Resource types with a plain string secret take " +
+					"`{\"password\": \"...\"}`. Written on create and whenever `secret_json_version` changes.",
+			},
+			"secret_json_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version tracker for `secret_json`. Increment it to write `secret_json` again.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan validates metadata and secret_json against the resource type schemas. Validation is skipped while
+// values or provider credentials are not known yet.
+func (r *genericResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() || r.client == nil || r.client.ConfigError != nil {
+		return
+	}
+
+	var config genericResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ResourceType.IsUnknown() {
+		return
+	}
+
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	resourceType, err := findPassboltResourceTypeBySlug(ctx, r.client, config.ResourceType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("resource_type"), "Unknown resource type", err.Error())
+
+		return
+	}
+
+	resourceSchema, err := tools.CompileResourceTypeSchema(*resourceType)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("resource_type"), "Unsupported resource type", err.Error())
+
+		return
+	}
+
+	metadata, err := genericResourceMetadata(config.Metadata)
+	switch {
+	case errors.Is(err, errUnknownDynamicValue):
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Invalid metadata", err.Error())
+	default:
+		metadata = withV5Fields(resourceType, metadata, api.PassboltObjectTypeResourceMetadata)
+		if err := resourceSchema.ValidateMetadata(metadata); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Invalid metadata", err.Error())
+		}
+	}
+
+	if config.SecretJSON.IsUnknown() {
+		return
+	}
+
+	secret, err := parseGenericSecretJSON(config.SecretJSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("secret_json"), "Invalid secret_json", err.Error())
+
+		return
+	}
+
+	if !resourceSchema.SecretIsString {
+		secret = withV5Fields(resourceType, secret, api.PassboltObjectTypeSecretData)
+	}
+	if err := resourceSchema.ValidateSecret(secret); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("secret_json"), "Invalid secret_json", err.Error())
+	}
+}
+
+func (r *genericResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var config genericResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan genericResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folderID, diags := resolveFolderID(ctx, r.client, plan.FolderParent)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, err := genericResourceMetadata(plan.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Invalid metadata", err.Error())
+
+		return
+	}
+
+	secret, err := parseGenericSecretJSON(config.SecretJSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("secret_json"), "Invalid secret_json", err.Error())
+
+		return
+	}
+
+	resourceID, err := helper.CreateResourceGeneric(
+		ctx,
+		r.client.Client,
+		plan.ResourceType.ValueString(),
+		folderID,
+		metadata,
+		secret,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot create resource", err.Error())
+
+		return
+	}
+
+	plan.ID = types.StringValue(resourceID)
+	plan.SecretJSON = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *genericResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state genericResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
+	resourceData, err := r.client.Client.GetResource(ctx, state.ID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+		resp.Diagnostics.AddError("Cannot read resource", err.Error())
+
+		return
+	}
+
+	resourceType, err := findPassboltResourceTypeByID(ctx, r.client, resourceData.ResourceTypeID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read resource type", err.Error())
+
+		return
+	}
+
+	// The secret is write-only, so only the metadata is decrypted.
+	_, remoteMetadata, _, err := helper.GetResourceFieldMaps(
+		r.client.Client,
+		*resourceData,
+		api.Secret{},
+		*resourceType,
+		false,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read resource metadata", err.Error())
+
+		return
+	}
+	remoteMetadata = withoutNormalizedFields(resourceType, remoteMetadata)
+
+	state.ResourceType = types.StringValue(resourceType.Slug)
+
+	priorMetadata, err := genericResourceMetadata(state.Metadata)
+	if err != nil || !jsonObjectsEquivalent(priorMetadata, remoteMetadata) {
+		metadata, err := jsonToDynamic(remoteMetadata)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot read resource metadata", err.Error())
+
+			return
+		}
+
+		state.Metadata = types.DynamicValue(metadata)
+	}
+
+	folderID, diags := resolveFolderID(ctx, r.client, state.FolderParent)
+	if diags.HasError() || folderID != resourceData.FolderParentID {
+		state.FolderParent = pickOptional(resourceData.FolderParentID)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *genericResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var config, plan, state genericResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metadata, err := genericResourceMetadata(plan.Metadata)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metadata"), "Invalid metadata", err.Error())
+
+		return
+	}
+
+	var secret map[string]any
+	if !plan.SecretJSONVersion.Equal(state.SecretJSONVersion) {
+		secret, err = parseGenericSecretJSON(config.SecretJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("secret_json"), "Invalid secret_json", err.Error())
+
+			return
+		}
+	}
+
+	if err := updateGenericResource(ctx, r.client, state.ID.ValueString(), metadata, secret); err != nil {
+		resp.Diagnostics.AddError("Error updating resource", err.Error())
+
+		return
+	}
+
+	if !plan.FolderParent.Equal(state.FolderParent) {
+		folderID, diags := resolveFolderID(ctx, r.client, plan.FolderParent)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := r.client.Client.MoveResource(ctx, state.ID.ValueString(), folderID); err != nil {
+			resp.Diagnostics.AddError("Error moving resource to folder", err.Error())
+
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	plan.SecretJSON = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *genericResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state genericResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Client.DeleteResource(ctx, state.ID.ValueString())
+	if err != nil {
+		if !isNotFoundError(err) {
+			resp.Diagnostics.AddError("Error deleting resource", err.Error())
+		}
+	}
+}
+
+func (r *genericResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func (r *genericResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the Passbolt resource.")
+}
+
+// genericResourceMetadata returns the configured metadata as a JSON object.
+func genericResourceMetadata(value types.Dynamic) (map[string]any, error) {
+	decoded, err := dynamicToJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("metadata must be an object, got %T", decoded)
+	}
+
+	return metadata, nil
+}
+
+func parseGenericSecretJSON(secretJSON string) (map[string]any, error) {
+	var secret map[string]any
+	if err := json.Unmarshal([]byte(secretJSON), &secret); err != nil {
+		return nil, fmt.Errorf("secret_json must be a JSON object: %w", err)
+	}
+
+	return secret, nil
+}
+
+// withV5Fields adds the fields go-passbolt sets on v5 metadata and secrets, so the object validates the same way
+// it will when it is sent.
+func withV5Fields(resourceType *api.ResourceType, fields map[string]any, objectType string) map[string]any {
+	if !resourceType.IsV5() {
+		return fields
+	}
+
+	result := make(map[string]any, len(fields)+2)
+	for key, value := range fields {
+		result[key] = value
+	}
+	result["object_type"] = objectType
+	if objectType == api.PassboltObjectTypeResourceMetadata {
+		result["resource_type_id"] = resourceType.ID
+	}
+
+	return result
+}
+
+// withoutNormalizedFields removes the fields go-passbolt adds to decrypted metadata but that the resource type does
+// not define, along with the fields the provider manages itself.
+func withoutNormalizedFields(resourceType *api.ResourceType, metadata map[string]any) map[string]any {
+	result := make(map[string]any, len(metadata))
+	for key, value := range metadata {
+		if (key == "uri" || key == "description") && !resourceType.HasMetadataField(key) {
+			continue
+		}
+
+		result[key] = value
+	}
+
+	for _, key := range genericResourceManagedFields {
+		delete(result, key)
+	}
+
+	return result
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"terraform-provider-passbolt/tools"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestDynamicJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	metadata := decodeFieldMap(t, map[string]any{
+		"name":     "db",
+		"uris":     []any{"https://db.example.com", "https://db2.example.com"},
+		"icon":     map[string]any{"type": passwordIconSet, "value": 7},
+		"archived": false,
+	})
+
+	value, err := jsonToDynamic(metadata)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := genericResourceMetadata(types.DynamicValue(value))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !jsonObjectsEquivalent(metadata, got) {
+		t.Fatalf("expected %#v, got %#v", metadata, got)
+	}
+}
+
+func TestGenericResourceMetadataRejectsNonObjects(t *testing.T) {
+	t.Parallel()
+
+	if _, err := genericResourceMetadata(types.DynamicValue(types.StringValue("db"))); err == nil {
+		t.Fatal("expected a string to be rejected as metadata")
+	}
+
+	_, err := genericResourceMetadata(types.DynamicUnknown())
+	if !errors.Is(err, errUnknownDynamicValue) {
+		t.Fatalf("expected an unknown value error, got %v", err)
+	}
+}
+
+func TestJSONObjectsEquivalentIgnoresEmptyMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		a, b map[string]any
+		want bool
+	}{
+		"null member": {
+			a:    map[string]any{"name": "db"},
+			b:    map[string]any{"name": "db", "description": nil},
+			want: true,
+		},
+		"empty string member": {
+			a:    map[string]any{"name": "db", "username": ""},
+			b:    map[string]any{"name": "db"},
+			want: true,
+		},
+		"different value": {
+			a: map[string]any{"name": "db"},
+			b: map[string]any{"name": "cache"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := jsonObjectsEquivalent(tt.a, tt.b); got != tt.want {
+				t.Fatalf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithoutNormalizedFieldsDropsFieldsMissingFromSchema(t *testing.T) {
+	t.Parallel()
+
+	resourceType := &api.ResourceType{ID: "type-id", Slug: "v5-default", Definition: []byte(`[]`)}
+	metadata := map[string]any{
+		"name":             "db",
+		"uris":             []any{"https://db.example.com"},
+		"uri":              "https://db.example.com",
+		"object_type":      api.PassboltObjectTypeResourceMetadata,
+		"resource_type_id": "type-id",
+	}
+
+	got := withoutNormalizedFields(resourceType, metadata)
+	for _, key := range []string{"uri", "object_type", "resource_type_id"} {
+		if _, ok := got[key]; ok {
+			t.Fatalf("expected %s to be removed, got %#v", key, got)
+		}
+	}
+	if got["name"] != "db" || got["uris"] == nil {
+		t.Fatalf("expected schema fields to be kept, got %#v", got)
+	}
+
+	validated := withV5Fields(resourceType, map[string]any{"name": "db"}, api.PassboltObjectTypeResourceMetadata)
+	if validated["object_type"] != api.PassboltObjectTypeResourceMetadata || validated["resource_type_id"] != "type-id" {
+		t.Fatalf("expected v5 fields to be added, got %#v", validated)
+	}
+}
+
+func TestGenericResourceSecretDataRemovesFields(t *testing.T) {
+	t.Parallel()
+
+	resourceType := &api.ResourceType{ID: "type-id", Slug: "v5-default", Definition: []byte(`[]`)}
+	resourceSchema, err := tools.CompileResourceTypeSchema(*resourceType)
+	if err != nil {
+		t.Fatalf("compiling schema: %v", err)
+	}
+
+	secretData, err := genericResourceSecretData(
+		context.Background(),
+		nil,
+		"resource-id",
+		resourceType,
+		resourceSchema,
+		map[string]any{"password": "secret"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var secret map[string]any
+	if err := json.Unmarshal([]byte(secretData), &secret); err != nil {
+		t.Fatalf("decoding secret: %v", err)
+	}
+	want := map[string]any{"object_type": api.PassboltObjectTypeSecretData, "password": "secret"}
+	if !maps.Equal(secret, want) {
+		t.Fatalf("expected %#v, got %#v", want, secret)
+	}
+}
+
+func TestGenericResourceCleartextField(t *testing.T) {
+	t.Parallel()
+
+	resourceType := &api.ResourceType{
+		Slug: "password-and-description",
+		Definition: []byte(`{"resource": {"properties": {"name": {}, "username": {}, "uri": {}}}, ` +
+			`"secret": {"properties": {"password": {}, "description": {}}}}`),
+	}
+	metadata := map[string]any{"name": "db", "username": "admin"}
+
+	if got := genericResourceCleartextField(resourceType, metadata, "username"); got == nil || *got != "admin" {
+		t.Fatalf("expected username admin, got %v", got)
+	}
+	if got := genericResourceCleartextField(resourceType, metadata, "uri"); got == nil || *got != "" {
+		t.Fatalf("expected a removed uri to be cleared, got %v", got)
+	}
+	if got := genericResourceCleartextField(resourceType, metadata, "description"); got != nil {
+		t.Fatalf("expected description, which the secret holds, to be left out, got %q", *got)
+	}
+}
@@ -0,0 +1,261 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestAccGenericResource_totpStandalone(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	resourceName := testAccName("acc-generic-test", testAccSuffix())
+
+	config := func(uri string, digits int) string {
+		return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_resource" "example" {
+  resource_type = "v5-totp-standalone"
+
+  metadata = {
+    name = "%s"
+    uris = ["%s"]
+  }
+
+  secret_json = jsonencode({
+    totp = {
+      secret_key = "JBSWY3DPEHPK3PXP"
+      algorithm  = "SHA1"
+      digits     = %d
+      period     = 30
+    }
+  })
+  secret_json_version = %d
+}
+`, baseURL, privateKey, passphrase, resourceName, uri, digits, digits)
+	}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("https://totp.example.com", 5),
+				ExpectError: regexp.MustCompile(`Invalid secret_json`),
+			},
+			{
+				Config: config("https://totp.example.com", 6),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"passbolt_resource.example",
+						tfjsonpath.New("metadata").AtMapKey("name"),
+						knownvalue.StringExact(resourceName),
+					),
+					statecheck.ExpectKnownValue(
+						"passbolt_resource.example",
+						tfjsonpath.New("secret_json"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: config("https://totp2.example.com", 8),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"passbolt_resource.example",
+						tfjsonpath.New("metadata").AtMapKey("uris").AtSliceIndex(0),
+						knownvalue.StringExact("https://totp2.example.com"),
+					),
+				},
+			},
+			{
+				ResourceName:            "passbolt_resource.example",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_json", "secret_json_version"},
+			},
+			{
+				Config: config("https://totp2.example.com", 8),
+				Check: testCheckDeletePassboltResource(
+					t,
+					baseURL,
+					privateKey,
+					passphrase,
+					"passbolt_resource.example",
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccGenericResource_removeFields(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	resourceName := testAccName("acc-generic-remove", testAccSuffix())
+
+	config := func(metadata, secret string, version int) string {
+		return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_resource" "example" {
+  resource_type = "v5-default"
+
+  metadata = {
+    name     = "%s"
+    username = "admin"
+    %s
+  }
+
+  secret_json = jsonencode({
+    password = "secret"
+    %s
+  })
+  secret_json_version = %d
+}
+`, baseURL, privateKey, passphrase, resourceName, metadata, secret, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`uris = ["https://db.example.com"]`, `description = "primary database"`, 1),
+				Check: testCheckPassboltResourceSecret(
+					baseURL,
+					privateKey,
+					passphrase,
+					"passbolt_resource.example",
+					`{"object_type":"PASSBOLT_SECRET_DATA","password":"secret","description":"primary database"}`,
+				),
+			},
+			{
+				Config: config("", "", 2),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"passbolt_resource.example",
+						tfjsonpath.New("metadata"),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"name":     knownvalue.StringExact(resourceName),
+							"username": knownvalue.StringExact("admin"),
+						}),
+					),
+				},
+				Check: testCheckPassboltResourceSecret(
+					baseURL,
+					privateKey,
+					passphrase,
+					"passbolt_resource.example",
+					`{"object_type":"PASSBOLT_SECRET_DATA","password":"secret"}`,
+				),
+			},
+			{
+				Config:   config("", "", 2),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testCheckPassboltResourceSecret(
+	baseURL, privateKey, passphrase, resourceName, expected string,
+) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		ctx := context.Background()
+		client, err := api.NewClient(nil, "", baseURL, privateKey, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to create Passbolt API client: %w", err)
+		}
+		if err := client.Login(ctx); err != nil {
+			return fmt.Errorf("failed to log in to Passbolt API: %w", err)
+		}
+		defer func() {
+			_ = client.Logout(ctx)
+		}()
+
+		secret, err := client.GetSecret(ctx, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get secret: %w", err)
+		}
+		decrypted, err := client.DecryptMessage(secret.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt secret: %w", err)
+		}
+
+		var got, want map[string]any
+		if err := json.Unmarshal([]byte(decrypted), &got); err != nil {
+			return fmt.Errorf("failed to decode secret: %w", err)
+		}
+		if err := json.Unmarshal([]byte(expected), &want); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("expected secret fields %v, got %v", want, got)
+		}
+
+		return nil
+	}
+}
+
+func testCheckDeletePassboltResource(
+	t *testing.T,
+	baseURL, privateKey, passphrase, resourceName string,
+) resource.TestCheckFunc {
+	t.Helper()
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		testDeletePassboltResource(t, baseURL, privateKey, passphrase, rs.Primary.ID)
+
+		return nil
+	}
+}
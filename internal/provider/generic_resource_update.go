@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"terraform-provider-passbolt/tools"
+	"time"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// genericResourceUpdateRequest is the body of a resource update. The v4 cleartext fields are pointers so that
+// removing them from metadata clears them in Passbolt.
+type genericResourceUpdateRequest struct {
+	ID              string              `json:"id"`
+	ResourceTypeID  string              `json:"resource_type_id"`
+	Name            string              `json:"name,omitempty"`
+	Username        *string             `json:"username,omitempty"`
+	URI             *string             `json:"uri,omitempty"`
+	Description     *string             `json:"description,omitempty"`
+	MetadataKeyID   string              `json:"metadata_key_id,omitempty"`
+	MetadataKeyType api.MetadataKeyType `json:"metadata_key_type,omitempty"`
+	Metadata        string              `json:"metadata,omitempty"`
+	Secrets         []api.Secret        `json:"secrets"`
+	Expired         *api.Time           `json:"expired,omitempty"`
+}
+
+// updateGenericResource replaces the metadata of a resource and, when secret is not nil, its secret. Unlike
+// helper.UpdateResourceGeneric, which merges updates into the current values, keys missing from metadata or secret
+// are removed instead of being written as null, which most resource type schemas reject.
+func updateGenericResource(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	resourceID string,
+	metadata map[string]any,
+	secret map[string]any,
+) error {
+	resourceData, resourceType, err := loadPasswordResourceType(ctx, client, resourceID)
+	if err != nil {
+		return err
+	}
+
+	resourceSchema, err := tools.CompileResourceTypeSchema(*resourceType)
+	if err != nil {
+		return err
+	}
+
+	request := genericResourceUpdateRequest{
+		ID:             resourceID,
+		ResourceTypeID: resourceData.ResourceTypeID,
+	}
+	err = setGenericResourceMetadata(ctx, client, &request, resourceData, resourceType, resourceSchema, metadata)
+	if err != nil {
+		return err
+	}
+
+	secretData, err := genericResourceSecretData(ctx, client, resourceID, resourceType, resourceSchema, secret)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.Client.GetUsers(ctx, &api.GetUsersOptions{
+		FilterHasAccess: []string{resourceID},
+	})
+	if err != nil {
+		return fmt.Errorf("getting users: %w", err)
+	}
+
+	request.Secrets, err = encryptSecretDataForUsers(client.Client, users, secretData)
+	if err != nil {
+		return err
+	}
+
+	expirySettings := client.Client.GetPasswordExpirySettings()
+	if secret != nil && resourceData.Expired != nil && expirySettings.AutomaticUpdate {
+		expiry := time.Duration(expirySettings.DefaultExpiryPeriod) * 24 * time.Hour
+		request.Expired = &api.Time{Time: time.Now().Add(expiry)}
+	}
+
+	_, err = client.Client.DoCustomRequestV5(ctx, "PUT", "/resources/"+resourceID+".json", request, nil)
+	if err != nil {
+		return fmt.Errorf("updating resource: %w", err)
+	}
+
+	return nil
+}
+
+// setGenericResourceMetadata validates metadata and sets it on request, encrypted for v5 resource types and as
+// cleartext fields for v4 ones.
+func setGenericResourceMetadata(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	request *genericResourceUpdateRequest,
+	resourceData *api.Resource,
+	resourceType *api.ResourceType,
+	resourceSchema *tools.ResourceTypeSchema,
+	metadata map[string]any,
+) error {
+	metadata = withV5Fields(resourceType, metadata, api.PassboltObjectTypeResourceMetadata)
+	if err := resourceSchema.ValidateMetadata(metadata); err != nil {
+		return err
+	}
+
+	if !resourceType.IsV5() {
+		request.Name, _ = metadata["name"].(string)
+		request.Username = genericResourceCleartextField(resourceType, metadata, "username")
+		request.URI = genericResourceCleartextField(resourceType, metadata, "uri")
+		request.Description = genericResourceCleartextField(resourceType, metadata, "description")
+
+		return nil
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("marshaling metadata: %w", err)
+	}
+
+	personal := resourceData.MetadataKeyType != api.MetadataKeyTypeSharedKey
+	keyID, keyType, publicKey, err := client.Client.GetMetadataKey(ctx, personal)
+	if err != nil {
+		return fmt.Errorf("get metadata key: %w", err)
+	}
+
+	request.MetadataKeyID = keyID
+	request.MetadataKeyType = keyType
+	request.Metadata, err = client.Client.EncryptMessageWithKey(publicKey, string(encoded))
+	if err != nil {
+		return fmt.Errorf("encrypt metadata: %w", err)
+	}
+
+	return nil
+}
+
+// genericResourceCleartextField returns the value of a v4 metadata field, or "" when it was removed. Fields the
+// resource type does not define are left out.
+func genericResourceCleartextField(resourceType *api.ResourceType, metadata map[string]any, key string) *string {
+	if !resourceType.HasMetadataField(key) {
+		return nil
+	}
+
+	value, _ := metadata[key].(string)
+
+	return &value
+}
+
+// genericResourceSecretData returns the secret to store: secret encoded for the resource type, or the current
+// secret when secret is nil.
+func genericResourceSecretData(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	resourceID string,
+	resourceType *api.ResourceType,
+	resourceSchema *tools.ResourceTypeSchema,
+	secret map[string]any,
+) (string, error) {
+	if secret == nil {
+		return readDecryptedSecret(ctx, client, resourceID)
+	}
+
+	if !resourceSchema.SecretIsString {
+		secret = withV5Fields(resourceType, secret, api.PassboltObjectTypeSecretData)
+	}
+	if err := resourceSchema.ValidateSecret(secret); err != nil {
+		return "", err
+	}
+
+	if resourceSchema.SecretIsString {
+		password, _ := secret["password"].(string)
+
+		return password, nil
+	}
+
+	encoded, err := json.Marshal(secret)
+	if err != nil {
+		return "", fmt.Errorf("marshaling secret: %w", err)
+	}
+
+	return string(encoded), nil
+}
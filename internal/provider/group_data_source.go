@@ -61,13 +61,17 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 }
 
 func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !ensureLogin(ctx, d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var config groupDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	groups, err := d.client.Client.GetGroups(ctx, nil)
+	groups, err := d.client.Groups(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to get groups", err.Error())
 
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &groupListResource{}
+	_ list.ListResourceWithConfigure = &groupListResource{}
+)
+
+// NewGroupListResource returns a list resource that enumerates Passbolt groups for terraform query.
+func NewGroupListResource() list.ListResource {
+	return &groupListResource{}
+}
+
+type groupListResource struct {
+	client *tools.PassboltClient
+}
+
+type groupListResourceModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+func (r *groupListResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	r.client = configureListResourceClient(req, resp)
+}
+
+func (r *groupListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (r *groupListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists Passbolt groups, sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list groups whose name starts with this prefix.",
+			},
+		},
+	}
+}
+
+func (r *groupListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	diags := ensureListLogin(ctx, r.client)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	var config groupListResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	groups, err := r.client.Groups(ctx)
+	if err != nil {
+		diags.AddError("Failed to get groups", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	items := make([]listItem, 0, len(groups))
+	for _, group := range groups {
+		if !listNameMatches(group.Name, config.NamePrefix) {
+			continue
+		}
+
+		items = append(items, listItem{
+			ID:          group.ID,
+			DisplayName: group.Name,
+			Attributes: map[string]any{
+				"name": group.Name,
+			},
+		})
+	}
+
+	slices.SortFunc(items, func(a, b listItem) int {
+		return cmp.Or(cmp.Compare(a.DisplayName, b.DisplayName), cmp.Compare(a.ID, b.ID))
+	})
+
+	stream.Results = streamListItems(ctx, req, items)
+}
@@ -27,6 +27,7 @@ var (
 	_ resource.Resource                = &groupResource{}
 	_ resource.ResourceWithConfigure   = &groupResource{}
 	_ resource.ResourceWithImportState = &groupResource{}
+	_ resource.ResourceWithIdentity    = &groupResource{}
 )
 
 // NewGroupResource returns a Terraform resource for managing Passbolt groups.
@@ -68,7 +69,21 @@ func (r *groupResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	importByIDOrIdentity(ctx, req, resp, func(id string) (string, diag.Diagnostics) {
+		return resolveGroupImportID(ctx, r.client, id)
+	})
+}
+
+func (r *groupResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the Passbolt group.")
 }
 
 func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -126,6 +141,10 @@ func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 }
 
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan groupModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -159,6 +178,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	ops := buildCreateGroupMembershipOps(managers, appliedMembers)
 
 	groupID, err := helper.CreateGroup(ctx, r.client.Client, plan.Name.ValueString(), ops)
+	r.client.Invalidate(tools.CacheGroups)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating group", err.Error())
 
@@ -170,15 +190,22 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.Members = setStringValue(desiredMembers)
 	plan.IgnoreInactiveMembers = types.BoolValue(plan.IgnoreInactiveMembers.ValueBool())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state groupModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
 	name, memberships, err := helper.GetGroup(ctx, r.client.Client, state.ID.ValueString())
 	if err != nil {
 		resp.State.RemoveResource(ctx)
@@ -198,6 +225,10 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan groupModel
 	var state groupModel
 	var configMembers types.Set
@@ -246,6 +277,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	if shouldUpdateGroup(plan.Name.ValueString(), currentName, ops) {
 		err = updateGroup(ctx, r.client.Client, state.ID.ValueString(), plan.Name.ValueString(), ops)
+		r.client.Invalidate(tools.CacheGroups)
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating group", err.Error())
 
@@ -258,6 +290,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	plan.Members = setStringValue(desiredMembers)
 	plan.IgnoreInactiveMembers = types.BoolValue(plan.IgnoreInactiveMembers.ValueBool())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func resolveGroupMembersForApply(
@@ -622,6 +655,10 @@ func groupUserIDs(roleMaps ...map[string]bool) []string {
 }
 
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state groupModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -629,6 +666,7 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	err := helper.DeleteGroup(ctx, r.client.Client, state.ID.ValueString())
+	r.client.Invalidate(tools.CacheGroups)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting group", err.Error())
 	}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	stdpath "path"
+	"sort"
+	"strings"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// isImportUUID reports whether an import ID is already a UUID and can be used as is.
+func isImportUUID(id string) bool {
+	return uuid.Validate(id) == nil
+}
+
+// resolvePasswordImportID resolves an import ID of the form /Folder/Path/Name, or /Name for a password at the top
+// level, to the UUID of a password.
+func resolvePasswordImportID(ctx context.Context, client *tools.PassboltClient, id string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if isImportUUID(id) {
+		return id, diags
+	}
+
+	folderPath, name, err := splitPasswordImportPath(id)
+	if err != nil {
+		diags.AddError("Invalid import ID", err.Error())
+
+		return "", diags
+	}
+
+	folders, err := getCachedPassboltFolders(ctx, client)
+	if err != nil {
+		diags.AddError("Cannot get folders", err.Error())
+
+		return "", diags
+	}
+
+	folderID := ""
+	var opts *api.GetResourcesOptions
+	if folderPath != "/" {
+		folderID, err = resolveFolderReferenceByPath(folders, folderPath)
+		if err != nil {
+			diags.AddError("Invalid import ID", err.Error())
+
+			return "", diags
+		}
+
+		opts = &api.GetResourcesOptions{FilterHasParent: []string{folderID}}
+	}
+
+	resources, err := client.Client.GetResources(ctx, opts)
+	if err != nil {
+		diags.AddError("Unable to Read resources", err.Error())
+
+		return "", diags
+	}
+
+	entries, err := decryptPassboltPasswords(ctx, client, resources, false, func(resource api.Resource) bool {
+		return resource.FolderParentID == folderID
+	})
+	if err != nil {
+		diags.AddError("Unable to Read resources", err.Error())
+
+		return "", diags
+	}
+
+	resourceID, err := resolvePasswordImportEntry(entries, id, name)
+	if err != nil {
+		diags.AddError("Unable to find password", err.Error())
+
+		return "", diags
+	}
+
+	return resourceID, diags
+}
+
+// splitPasswordImportPath splits /Folder/Path/Name into the folder path and the password name. Password names
+// that contain a slash must be imported by UUID.
+func splitPasswordImportPath(id string) (string, string, error) {
+	if !strings.HasPrefix(id, "/") {
+		return "", "", fmt.Errorf("import ID %q must be a UUID or a path such as /Folder/Path/Name", id)
+	}
+	if hasRelativePathSegments(id) {
+		return "", "", fmt.Errorf("import ID %q must not contain '.' or '..' segments", id)
+	}
+
+	folderPath, name := stdpath.Split(id)
+	if name == "" {
+		return "", "", fmt.Errorf("import ID %q must end with the password name", id)
+	}
+
+	folderPath = stdpath.Clean(folderPath)
+
+	return folderPath, name, nil
+}
+
+func resolvePasswordImportEntry(entries []passboltPasswordEntry, id string, name string) (string, error) {
+	matches := make([]string, 0, 1)
+	for _, entry := range entries {
+		if entry.Name == name {
+			matches = append(matches, entry.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("password %q not found", id)
+	case 1:
+		return matches[0], nil
+	}
+
+	sort.Strings(matches)
+
+	return "", fmt.Errorf(
+		"password %q is ambiguous; import by UUID instead. Matches: %s",
+		id,
+		strings.Join(matches, ", "),
+	)
+}
+
+// resolveFolderImportID resolves an absolute folder path or a unique folder name to the UUID of a folder.
+func resolveFolderImportID(ctx context.Context, client *tools.PassboltClient, id string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if isImportUUID(id) {
+		return id, diags
+	}
+
+	folders, err := getCachedPassboltFolders(ctx, client)
+	if err != nil {
+		diags.AddError("Cannot get folders", err.Error())
+
+		return "", diags
+	}
+
+	folderID, err := resolveFolderReferenceValue(folders, id)
+	if err != nil {
+		diags.AddError("Invalid import ID", err.Error())
+
+		return "", diags
+	}
+	if folderID == "" {
+		diags.AddError("Invalid import ID", "import ID cannot be empty")
+	}
+
+	return folderID, diags
+}
+
+// resolveGroupImportID resolves a group name to the UUID of a group.
+func resolveGroupImportID(ctx context.Context, client *tools.PassboltClient, id string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if isImportUUID(id) {
+		return id, diags
+	}
+
+	groups, err := client.Groups(ctx)
+	if err != nil {
+		diags.AddError("Failed to get groups", err.Error())
+
+		return "", diags
+	}
+
+	groupID, err := groupIDByName(groups, id)
+	if err != nil {
+		diags.AddError("Invalid import ID", err.Error())
+
+		return "", diags
+	}
+
+	return groupID, diags
+}
+
+func groupIDByName(groups []api.Group, name string) (string, error) {
+	matches := make([]string, 0, 1)
+	for _, group := range groups {
+		if group.Name == name {
+			matches = append(matches, group.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no group found with name %q", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	sort.Strings(matches)
+
+	return "", fmt.Errorf(
+		"group name %q is ambiguous; import by UUID instead. Matches: %s",
+		name,
+		strings.Join(matches, ", "),
+	)
+}
+
+// resolveUserImportID resolves a user's email address to the UUID of the user. Inactive users can be imported.
+func resolveUserImportID(ctx context.Context, client *tools.PassboltClient, id string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if isImportUUID(id) {
+		return id, diags
+	}
+
+	users, err := client.Users(ctx)
+	if err != nil {
+		diags.AddError("Failed to get users", err.Error())
+
+		return "", diags
+	}
+
+	user, err := userByUsername(users, id, true)
+	if err != nil {
+		diags.AddError("Invalid import ID", err.Error())
+
+		return "", diags
+	}
+
+	return user.ID, diags
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestSplitPasswordImportPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id         string
+		wantFolder string
+		wantName   string
+		wantErr    bool
+	}{
+		"nested":          {id: "/application_A/prod/db-admin", wantFolder: "/application_A/prod", wantName: "db-admin"},
+		"top level":       {id: "/db-admin", wantFolder: "/", wantName: "db-admin"},
+		"name with space": {id: "/prod/DB admin", wantFolder: "/prod", wantName: "DB admin"},
+		"relative":        {id: "prod/db-admin", wantErr: true},
+		"trailing slash":  {id: "/prod/", wantErr: true},
+		"dot segment":     {id: "/prod/../db-admin", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			folderPath, passwordName, err := splitPasswordImportPath(tt.id)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %q", tt.id)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if folderPath != tt.wantFolder || passwordName != tt.wantName {
+				t.Fatalf("expected %q and %q, got %q and %q", tt.wantFolder, tt.wantName, folderPath, passwordName)
+			}
+		})
+	}
+}
+
+func TestResolvePasswordImportEntry(t *testing.T) {
+	t.Parallel()
+
+	entries := []passboltPasswordEntry{
+		{ID: "a", Name: "db-admin"},
+		{ID: "b", Name: "api-key"},
+		{ID: "d", Name: "shared"},
+		{ID: "c", Name: "shared"},
+	}
+
+	got, err := resolvePasswordImportEntry(entries, "/prod/db-admin", "db-admin")
+	if err != nil || got != "a" {
+		t.Fatalf("expected a, got %q (%v)", got, err)
+	}
+
+	if _, err := resolvePasswordImportEntry(entries, "/prod/missing", "missing"); err == nil {
+		t.Fatal("expected an error for a missing password")
+	}
+
+	_, err = resolvePasswordImportEntry(entries, "/prod/shared", "shared")
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || !strings.Contains(err.Error(), "c, d") {
+		t.Fatalf("expected an ambiguity error listing both matches, got %v", err)
+	}
+}
+
+func TestGroupIDByName(t *testing.T) {
+	t.Parallel()
+
+	groups := []api.Group{
+		{ID: "1", Name: "DevOps"},
+		{ID: "2", Name: "Finance"},
+		{ID: "3", Name: "Finance"},
+	}
+
+	if got, err := groupIDByName(groups, "DevOps"); err != nil || got != "1" {
+		t.Fatalf("expected 1, got %q (%v)", got, err)
+	}
+	if _, err := groupIDByName(groups, "devops"); err == nil {
+		t.Fatal("expected group names to match exactly")
+	}
+	if _, err := groupIDByName(groups, "Finance"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected an ambiguity error, got %v", err)
+	}
+}
+
+func TestIsImportUUID(t *testing.T) {
+	t.Parallel()
+
+	if !isImportUUID("3c29bc5d-ffff-cccc-bbbb-abcdefabcdef") {
+		t.Fatal("expected a UUID to be used as is")
+	}
+	for _, id := range []string{"/application_A/prod", "DevOps", "operator@example.com"} {
+		if isImportUUID(id) {
+			t.Fatalf("expected %q to be resolved", id)
+		}
+	}
+}
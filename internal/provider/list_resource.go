@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listItem is one remote object returned by a list resource.
+type listItem struct {
+	ID          string
+	DisplayName string
+	// Attributes are set on the resource state when Terraform asks for full resources, for example to generate
+	// configuration.
+	Attributes map[string]any
+}
+
+// configureListResourceClient returns the provider client passed to a list resource.
+func configureListResourceClient(
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) *tools.PassboltClient {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltClient, got: %T", req.ProviderData),
+		)
+
+		return nil
+	}
+
+	return client
+}
+
+// ensureListLogin logs the provider in before a list resource reads from Passbolt.
+func ensureListLogin(ctx context.Context, client *tools.PassboltClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client == nil {
+		diags.AddError("Unconfigured Passbolt client", "The provider must be configured before listing resources.")
+
+		return diags
+	}
+
+	ensureLogin(ctx, client, &diags)
+
+	return diags
+}
+
+// streamListItems returns the results of a list resource, stopping at the limit Terraform asked for.
+func streamListItems(ctx context.Context, req list.ListRequest, items []listItem) func(func(list.ListResult) bool) {
+	return func(push func(list.ListResult) bool) {
+		for i, item := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = item.DisplayName
+			result.Diagnostics.Append(result.Identity.Set(ctx, idIdentityModel{ID: types.StringValue(item.ID)})...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(setListItemAttributes(ctx, result.Resource, item)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+func setListItemAttributes(ctx context.Context, state *tfsdk.Resource, item listItem) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), item.ID)...)
+	for name, value := range item.Attributes {
+		diags.Append(state.SetAttribute(ctx, path.Root(name), value)...)
+	}
+
+	return diags
+}
+
+// listNameMatches reports whether name starts with the configured prefix.
+func listNameMatches(name string, prefix types.String) bool {
+	return prefix.IsNull() || strings.HasPrefix(name, prefix.ValueString())
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestFolderListItems(t *testing.T) {
+	t.Parallel()
+
+	folders := []api.Folder{
+		{ID: "prod", Name: "prod"},
+		{ID: "db", Name: "db", FolderParentID: "prod"},
+		{ID: "replicas", Name: "replicas", FolderParentID: "db"},
+		{ID: "api", Name: "api", FolderParentID: "prod"},
+		{ID: "dev", Name: "dev"},
+	}
+
+	tests := map[string]struct {
+		config folderListResourceModel
+		want   []string
+	}{
+		"all": {
+			want: []string{"/dev", "/prod", "/prod/api", "/prod/db", "/prod/db/replicas"},
+		},
+		"children": {
+			config: folderListResourceModel{FolderPath: types.StringValue("/prod")},
+			want:   []string{"/prod/api", "/prod/db"},
+		},
+		"recursive": {
+			config: folderListResourceModel{FolderPath: types.StringValue("/prod"), Recursive: types.BoolValue(true)},
+			want:   []string{"/prod/api", "/prod/db", "/prod/db/replicas"},
+		},
+		"name prefix": {
+			config: folderListResourceModel{NamePrefix: types.StringValue("d")},
+			want:   []string{"/dev", "/prod/db"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := folderListItems(folders, tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, item := range items {
+				got = append(got, item.DisplayName)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFolderListItemsUnknownFolder(t *testing.T) {
+	t.Parallel()
+
+	_, err := folderListItems(
+		[]api.Folder{{ID: "prod", Name: "prod"}},
+		folderListResourceModel{FolderPath: types.StringValue("/missing")},
+	)
+	if err == nil {
+		t.Fatal("expected an error for a missing folder")
+	}
+}
+
+func TestPasswordListItems(t *testing.T) {
+	t.Parallel()
+
+	entries := []passboltPasswordEntry{
+		{ID: "b", Name: "db-admin", FolderParentID: "prod", Username: "admin"},
+		{ID: "a", Name: "api-key", FolderParentID: "prod", URI: "https://api.example.com"},
+		{ID: "c", Name: "root"},
+	}
+	pathsByID := map[string]string{"prod": "/prod"}
+
+	items := passwordListItems(entries, pathsByID, types.StringNull())
+	var got []string
+	for _, item := range items {
+		got = append(got, item.DisplayName)
+	}
+	if want := []string{"/prod/api-key", "/prod/db-admin", "/root"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if folderParent := items[2].Attributes["folder_parent"]; folderParent != types.StringNull() {
+		t.Fatalf("expected a null folder_parent at the top level, got %v", folderParent)
+	}
+
+	items = passwordListItems(entries, pathsByID, types.StringValue("db"))
+	if len(items) != 1 || items[0].ID != "b" {
+		t.Fatalf("expected only db-admin, got %v", items)
+	}
+}
+
+func TestStreamListItems(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	folderResource := NewFolderResource()
+
+	var schemaResp resource.SchemaResponse
+	folderResource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	var identityResp resource.IdentitySchemaResponse
+	folderResource.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+
+	items := []listItem{
+		{ID: "1", DisplayName: "/prod", Attributes: map[string]any{"name": "prod", "folder_parent": types.StringNull()}},
+		{
+			ID:          "2",
+			DisplayName: "/prod/db",
+			Attributes:  map[string]any{"name": "db", "folder_parent": types.StringValue("/prod")},
+		},
+		{
+			ID:          "3",
+			DisplayName: "/prod/api",
+			Attributes:  map[string]any{"name": "api", "folder_parent": types.StringValue("/prod")},
+		},
+	}
+	req := list.ListRequest{
+		IncludeResource:        true,
+		Limit:                  2,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+
+	var results []list.ListResult
+	for result := range streamListItems(ctx, req, items) {
+		results = append(results, result)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected the limit to stop at 2 results, got %d", len(results))
+	}
+
+	result := results[1]
+	if result.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+	if result.DisplayName != "/prod/db" {
+		t.Fatalf("expected display name /prod/db, got %q", result.DisplayName)
+	}
+
+	var identity idIdentityModel
+	if diags := result.Identity.Get(ctx, &identity); diags.HasError() {
+		t.Fatalf("unexpected identity diagnostics: %v", diags)
+	}
+	if identity.ID.ValueString() != "2" {
+		t.Fatalf("expected identity id 2, got %s", identity.ID)
+	}
+
+	var folderParent types.String
+	if diags := result.Resource.GetAttribute(ctx, path.Root("folder_parent"), &folderParent); diags.HasError() {
+		t.Fatalf("unexpected resource diagnostics: %v", diags)
+	}
+	if folderParent.ValueString() != "/prod" {
+		t.Fatalf("expected folder_parent /prod, got %s", folderParent)
+	}
+}
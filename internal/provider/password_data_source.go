@@ -3,18 +3,25 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"terraform-provider-passbolt/tools"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &passwordDataSource{}
-	_ datasource.DataSourceWithConfigure = &passwordDataSource{}
+	_ datasource.DataSource                     = &passwordDataSource{}
+	_ datasource.DataSourceWithConfigure        = &passwordDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &passwordDataSource{}
 )
 
 // NewPasswordDataSource is a helper function to simplify the provider implementation.
@@ -34,6 +41,8 @@ type passwordDataSourceModel struct {
 	Username       types.String `tfsdk:"username"`
 	URI            types.String `tfsdk:"uri"`
 	FolderParentID types.String `tfsdk:"folder_parent_id"`
+	FolderPath     types.String `tfsdk:"folder_path"`
+	FolderParent   types.String `tfsdk:"folder_parent"`
 	Password       types.String `tfsdk:"password"`
 }
 
@@ -71,21 +80,58 @@ func (d *passwordDataSource) Metadata(
 	resp.TypeName = req.ProviderTypeName + "_password"
 }
 
+func (d *passwordDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("folder_path"),
+			path.MatchRoot("folder_parent"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("folder_path"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("folder_parent"),
+		),
+	}
+}
+
 // Schema defines the schema for the data source.
 func (d *passwordDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetch a password/secret from Passbolt by its ID. Useful for lookups in cross-team automation " +
+		Description: "Fetch a password/secret from Passbolt by its ID, or by name and optional folder. Useful for " +
+			"lookups in cross-team automation " +
 			"or outputting secrets to other modules. Supports Passbolt v4 and v5 encrypted metadata resources. " +
 			"Returns all metadata and the decrypted secret value. The decrypted value remains sensitive in Terraform " +
 			"output, but Terraform will still persist it in state.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:    true,
-				Description: "The Passbolt resource UUID (you can get it from the Passbolt UI or list of resources).",
+				Optional: true,
+				Computed: true,
+				Description: "The Passbolt resource UUID (you can get it from the Passbolt UI or list of resources). " +
+					"Exactly one of `id` or `name` must be set.",
 			},
 			"name": schema.StringAttribute{
-				Computed:    true,
-				Description: "Name of the password/secret in Passbolt.",
+				Optional: true,
+				Computed: true,
+				Description: "Name of the password/secret in Passbolt. When set instead of `id`, the password is " +
+					"looked up by name, optionally narrowed down with `folder_path` or `folder_parent`. The lookup " +
+					"fails if several visible passwords match.",
+			},
+			"folder_path": schema.StringAttribute{
+				Optional: true,
+				Description: "Absolute path of the folder containing the password, such as `/application_A/prod`. " +
+					"Only used with `name`.",
+			},
+			"folder_parent": schema.StringAttribute{
+				Optional: true,
+				Description: "Unique name, UUID, or absolute path of the folder containing the password. Only used " +
+					"with `name`.",
 			},
 			"description": schema.StringAttribute{
 				Computed:    true,
@@ -115,9 +161,26 @@ func (d *passwordDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 
 // Read refreshes the Terraform state with the latest data.
 func (d *passwordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !ensureLogin(ctx, d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var data passwordDataSourceModel
 	diag := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ID.IsNull() {
+		resourceID, diags := lookupPasswordIDByName(ctx, d.client, data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.ID = types.StringValue(resourceID)
+	}
 
 	folderParentID, name, username, uri, password, description, err := helper.GetResource(
 		ctx,
@@ -146,3 +209,111 @@ func (d *passwordDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 }
+
+// lookupPasswordIDByName resolves the configured name and optional folder to a single resource UUID.
+func lookupPasswordIDByName(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	data passwordDataSourceModel,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	folders, err := getCachedPassboltFolders(ctx, client)
+	if err != nil {
+		diags.AddError("Cannot get folders", err.Error())
+
+		return "", diags
+	}
+
+	var opts *api.GetResourcesOptions
+	folderReference, err := passwordDataSourceFolderReference(data)
+	if err != nil {
+		diags.AddError("Invalid folder reference", err.Error())
+
+		return "", diags
+	}
+	if folderReference != "" {
+		folderID, err := resolveFolderReferenceValue(folders, folderReference)
+		if err != nil {
+			diags.AddError("Invalid folder reference", err.Error())
+
+			return "", diags
+		}
+
+		opts = &api.GetResourcesOptions{FilterHasParent: []string{folderID}}
+	}
+
+	resources, err := client.Client.GetResources(ctx, opts)
+	if err != nil {
+		diags.AddError("Unable to Read resources", err.Error())
+
+		return "", diags
+	}
+
+	entries, err := decryptPassboltPasswords(ctx, client, resources, false, nil)
+	if err != nil {
+		diags.AddError("Unable to Read resources", err.Error())
+
+		return "", diags
+	}
+
+	pathsByID, err := buildFolderPathIndex(folders)
+	if err != nil {
+		diags.AddError("Unable to build folder paths", err.Error())
+
+		return "", diags
+	}
+
+	resourceID, err := resolvePasswordEntryByName(entries, data.Name.ValueString(), pathsByID)
+	if err != nil {
+		diags.AddError("Unable to find password", err.Error())
+
+		return "", diags
+	}
+
+	return resourceID, diags
+}
+
+func passwordDataSourceFolderReference(data passwordDataSourceModel) (string, error) {
+	if !data.FolderPath.IsNull() {
+		if _, err := normalizeFolderPath(strings.TrimSpace(data.FolderPath.ValueString())); err != nil {
+			return "", err
+		}
+
+		return data.FolderPath.ValueString(), nil
+	}
+
+	return data.FolderParent.ValueString(), nil
+}
+
+func resolvePasswordEntryByName(
+	entries []passboltPasswordEntry,
+	name string,
+	pathsByID map[string]string,
+) (string, error) {
+	matches := make([]passboltPasswordEntry, 0, 1)
+	for _, entry := range entries {
+		if entry.Name == name {
+			matches = append(matches, entry)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("password with name %q not found", name)
+	case 1:
+		return matches[0].ID, nil
+	}
+
+	locations := make([]string, 0, len(matches))
+	for _, match := range matches {
+		locations = append(locations, fmt.Sprintf("%s/%s (%s)", pathsByID[match.FolderParentID], match.Name, match.ID))
+	}
+	sort.Strings(locations)
+
+	return "", fmt.Errorf(
+		"password name %q is ambiguous; set folder_path or folder_parent, or use id instead. Matches: %s",
+		name,
+		strings.Join(locations, ", "),
+	)
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolvePasswordEntryByName(t *testing.T) {
+	t.Parallel()
+
+	entries := []passboltPasswordEntry{
+		{ID: "top-id", Name: "db-admin"},
+		{ID: "prod-id", Name: "db-admin", FolderParentID: "prod"},
+		{ID: "web-id", Name: "web-admin", FolderParentID: "prod"},
+	}
+	pathsByID := map[string]string{"prod": "/app/prod"}
+
+	tests := map[string]struct {
+		entries []passboltPasswordEntry
+		name    string
+		want    string
+		wantErr string
+	}{
+		"single match": {
+			entries: entries,
+			name:    "web-admin",
+			want:    "web-id",
+		},
+		"not found": {
+			entries: entries,
+			name:    "missing",
+			wantErr: `password with name "missing" not found`,
+		},
+		"ambiguous": {
+			entries: entries,
+			name:    "db-admin",
+			wantErr: "Matches: /app/prod/db-admin (prod-id), /db-admin (top-id)",
+		},
+		"narrowed by folder": {
+			entries: entries[1:],
+			name:    "db-admin",
+			want:    "prod-id",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resolvePasswordEntryByName(test.entries, test.name, pathsByID)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestPasswordDataSourceFolderReference(t *testing.T) {
+	t.Parallel()
+
+	got, err := passwordDataSourceFolderReference(passwordDataSourceModel{
+		FolderPath:   types.StringValue("/app/prod"),
+		FolderParent: types.StringNull(),
+	})
+	if err != nil || got != "/app/prod" {
+		t.Fatalf("expected folder path reference, got %q (%v)", got, err)
+	}
+
+	got, err = passwordDataSourceFolderReference(passwordDataSourceModel{
+		FolderPath:   types.StringNull(),
+		FolderParent: types.StringValue("prod"),
+	})
+	if err != nil || got != "prod" {
+		t.Fatalf("expected folder parent reference, got %q (%v)", got, err)
+	}
+
+	_, err = passwordDataSourceFolderReference(passwordDataSourceModel{
+		FolderPath:   types.StringValue("prod"),
+		FolderParent: types.StringNull(),
+	})
+	if err == nil {
+		t.Fatal("expected relative folder_path to be rejected")
+	}
+}
@@ -3,6 +3,7 @@ package provider_test
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -50,3 +51,75 @@ data "passbolt_password" "by_id" {
 		},
 	})
 }
+
+func TestAccPasswordDataSource_byName(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	suffix := testAccSuffix()
+	folderName := testAccName("acc-ds-folder", suffix)
+	resourceName := testAccName("acc-ds-name", suffix)
+	config := fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_folder" "example" {
+  name = "%s"
+}
+
+resource "passbolt_password" "in_folder" {
+  name          = "%s"
+  username      = "ds-folder-user"
+  uri           = "https://ds.example.com"
+  password      = "ds-folder-secret"
+  folder_parent = passbolt_folder.example.id
+}
+
+resource "passbolt_password" "top_level" {
+  name     = "%s"
+  username = "ds-top-user"
+  uri      = "https://ds.example.com"
+  password = "ds-top-secret"
+}
+`, baseURL, privateKey, passphrase, folderName, resourceName, resourceName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config + fmt.Sprintf(`
+data "passbolt_password" "by_path" {
+  name        = "%s"
+  folder_path = "/%s"
+  depends_on  = [passbolt_password.in_folder, passbolt_password.top_level]
+}
+`, resourceName, folderName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.passbolt_password.by_path", "id",
+						"passbolt_password.in_folder", "id",
+					),
+					resource.TestCheckResourceAttr("data.passbolt_password.by_path", "username", "ds-folder-user"),
+				),
+			},
+			{
+				Config: config + fmt.Sprintf(`
+data "passbolt_password" "ambiguous" {
+  name       = "%s"
+  depends_on = [passbolt_password.in_folder, passbolt_password.top_level]
+}
+`, resourceName),
+				ExpectError: regexp.MustCompile(`is ambiguous`),
+			},
+		},
+	})
+}
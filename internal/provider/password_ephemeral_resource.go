@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &passwordEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &passwordEphemeralResource{}
+)
+
+// NewPasswordEphemeralResource returns a Terraform ephemeral resource for reading Passbolt secrets.
+func NewPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &passwordEphemeralResource{}
+}
+
+// passwordEphemeralResource is the ephemeral resource implementation.
+type passwordEphemeralResource struct {
+	client *tools.PassboltClient
+}
+
+type passwordEphemeralResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Username       types.String `tfsdk:"username"`
+	URI            types.String `tfsdk:"uri"`
+	FolderParentID types.String `tfsdk:"folder_parent_id"`
+	Password       types.String `tfsdk:"password"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *passwordEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *PassboltClient, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *passwordEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_password"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *passwordEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a password/secret from Passbolt by its ID without persisting it. Supports Passbolt v4 " +
+			"and v5 encrypted metadata resources. Terraform never stores ephemeral results in plan or state, so " +
+			"use this to feed Passbolt secrets into write-only arguments or provider configuration blocks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Passbolt resource UUID (you can get it from the Passbolt UI or list of resources).",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the password/secret in Passbolt.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "Description field for the secret.",
+			},
+			"username": schema.StringAttribute{
+				Computed:    true,
+				Description: "Username/login for this secret (if set).",
+			},
+			"uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "URI where the password is used, e.g., a service address.",
+			},
+			"folder_parent_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Parent folder's UUID containing this password/secret.",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The **actual secret value**. Only available during the Terraform run.",
+			},
+		},
+	}
+}
+
+// Open decrypts the requested secret and returns it for the current Terraform run only.
+func (e *passwordEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	if !ensureLogin(ctx, e.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data passwordEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	folderParentID, name, username, uri, password, description, err := helper.GetResource(
+		ctx,
+		e.client.Client,
+		data.ID.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read resource "+data.ID.ValueString(), err.Error(),
+		)
+
+		return
+	}
+
+	data.Name = types.StringValue(name)
+	data.Description = types.StringValue(description)
+	data.URI = types.StringValue(uri)
+	data.Username = types.StringValue(username)
+	data.FolderParentID = types.StringValue(folderParentID)
+	data.Password = types.StringValue(password)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// passwordIconSet is the icon set Passbolt uses for resource icons chosen by index.
+const passwordIconSet = "keepass-icon-set"
+
+// passwordCustomFieldNamespace derives stable custom field IDs from field keys, so the metadata and secret halves of
+// a field share an ID without tracking it in state.
+var passwordCustomFieldNamespace = uuid.MustParse("6f1d7a6e-3c1b-4a53-9a43-2c8e1f0b7d52")
+
+var passwordColorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type passwordCustomFieldModel struct {
+	Type        types.String `tfsdk:"type"`
+	Value       types.String `tfsdk:"value"`
+	SecretValue types.String `tfsdk:"secret_value"`
+}
+
+// passwordExtraFields are the v5 metadata and secret fields written next to name, username, password, and
+// description.
+type passwordExtraFields struct {
+	URIs []string
+	// Icon is nil when the icon is left alone.
+	Icon map[string]any
+	// MetadataCustomFields and SecretCustomFields are nil when custom fields are left alone, and empty to remove
+	// them.
+	MetadataCustomFields []map[string]any
+	SecretCustomFields   []map[string]any
+	TOTP                 *passwordTOTPModel
+}
+
+func passwordResourceV5Attributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"uris": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "URIs where the secret is used. Use instead of `uri` to store several endpoints. More than " +
+				"one URI requires v5 encrypted metadata.",
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+		},
+		"icon": schema.Int64Attribute{
+			Optional:    true,
+			Description: "Index of the resource icon in the KeePass icon set shown by Passbolt. Requires v5 encrypted metadata.",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"color": schema.StringAttribute{
+			Optional:    true,
+			Description: "Icon background color as a `#RRGGBB` hex value. Requires v5 encrypted metadata.",
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(passwordColorRegex, "must be a hex color such as #1A2B3C"),
+			},
+		},
+		"custom_fields": schema.MapNestedAttribute{
+			Optional: true,
+			Description: "Custom fields keyed by field name. Each field has either a `value`, stored in the resource " +
+				"metadata, or a `secret_value`, stored in the encrypted secret. Requires v5 encrypted metadata.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("text"),
+						Description: "Field type: `text`, `password`, `boolean`, `number`, or `uri`. Defaults to `text`.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("text", "password", "boolean", "number", "uri"),
+						},
+					},
+					"value": schema.StringAttribute{
+						Optional:    true,
+						Description: "Value stored in the resource metadata, visible to everyone with access.",
+						Validators: []validator.String{
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("secret_value")),
+						},
+					},
+					"secret_value": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Value stored in the encrypted secret. Stored in Terraform state like `password`.",
+					},
+				},
+			},
+			Validators: []validator.Map{
+				mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+			},
+		},
+	}
+}
+
+// passwordURIs returns the URIs configured through uri or uris.
+func passwordURIs(model passwordModel) []string {
+	if model.URIs != nil {
+		uris := make([]string, 0, len(model.URIs))
+		for _, uri := range model.URIs {
+			uris = append(uris, uri.ValueString())
+		}
+
+		return uris
+	}
+
+	return []string{model.URI.ValueString()}
+}
+
+func passwordURIsChanged(plan, state passwordModel) bool {
+	if plan.URI.IsUnknown() || slices.ContainsFunc(plan.URIs, types.String.IsUnknown) {
+		return true
+	}
+
+	return !slices.Equal(passwordURIs(plan), passwordURIs(state))
+}
+
+func hasPasswordIcon(model passwordModel) bool {
+	return !model.Icon.IsNull() || !model.Color.IsNull()
+}
+
+// passwordExtraFieldsFrom builds the extra fields to write for plan. Icons and custom fields that are only in state
+// are reset, so removing them from the configuration removes them in Passbolt.
+func passwordExtraFieldsFrom(plan, state passwordModel) (passwordExtraFields, error) {
+	extra := passwordExtraFields{
+		URIs: passwordURIs(plan),
+		TOTP: plan.TOTP,
+	}
+
+	if hasPasswordIcon(plan) || hasPasswordIcon(state) {
+		extra.Icon = map[string]any{"type": passwordIconSet}
+		if !plan.Icon.IsNull() {
+			extra.Icon["value"] = plan.Icon.ValueInt64()
+		}
+		if !plan.Color.IsNull() {
+			extra.Icon["background_color"] = plan.Color.ValueString()
+		}
+	}
+
+	if plan.CustomFields != nil || state.CustomFields != nil {
+		metadataFields, secretFields, err := passwordCustomFieldMaps(plan.CustomFields)
+		if err != nil {
+			return extra, err
+		}
+
+		extra.MetadataCustomFields = metadataFields
+		extra.SecretCustomFields = secretFields
+	}
+
+	return extra, nil
+}
+
+// requiresV5 reports why the fields cannot be stored with v4 cleartext metadata, or "" when they can.
+func (f passwordExtraFields) requiresV5() string {
+	switch {
+	case len(f.URIs) > 1:
+		return "uris with more than one URI"
+	case f.Icon != nil && len(f.Icon) > 1:
+		return "icon and color"
+	case len(f.MetadataCustomFields) > 0:
+		return "custom_fields"
+	default:
+		return ""
+	}
+}
+
+func (f passwordExtraFields) checkMetadataType(metadataType string) error {
+	if metadataType != metadataTypeV4 {
+		return nil
+	}
+
+	if reason := f.requiresV5(); reason != "" {
+		return fmt.Errorf("%s require v5 encrypted metadata, set metadata_type = \"v5\"", reason)
+	}
+
+	return nil
+}
+
+// apply adds the extra fields to the metadata and secret maps sent to Passbolt.
+func (f passwordExtraFields) apply(metadataFields, secretFields map[string]any, isV5 bool) {
+	if f.TOTP != nil {
+		secretFields["totp"] = f.TOTP.secretField()
+	}
+
+	if !isV5 {
+		return
+	}
+
+	if f.Icon != nil {
+		metadataFields["icon"] = f.Icon
+	}
+
+	if f.MetadataCustomFields != nil {
+		metadataFields["custom_fields"] = f.MetadataCustomFields
+		secretFields["custom_fields"] = f.SecretCustomFields
+	}
+}
+
+// passwordCustomFieldMaps splits custom fields into their metadata and secret halves. Keys always live in the
+// metadata, values on the side chosen in the configuration.
+func passwordCustomFieldMaps(
+	fields map[string]passwordCustomFieldModel,
+) ([]map[string]any, []map[string]any, error) {
+	metadataFields := make([]map[string]any, 0, len(fields))
+	secretFields := make([]map[string]any, 0, len(fields))
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		field := fields[key]
+		id := uuid.NewSHA1(passwordCustomFieldNamespace, []byte(key)).String()
+		fieldType := field.Type.ValueString()
+		if fieldType == "" {
+			fieldType = "text"
+		}
+
+		metadataField := map[string]any{
+			"id":           id,
+			"type":         fieldType,
+			"metadata_key": key,
+		}
+		secretField := map[string]any{
+			"id":           id,
+			"type":         fieldType,
+			"secret_value": nil,
+		}
+
+		if !field.Value.IsNull() {
+			value, err := customFieldValue(fieldType, field.Value.ValueString())
+			if err != nil {
+				return nil, nil, fmt.Errorf("custom field %q: %w", key, err)
+			}
+			metadataField["metadata_value"] = value
+		} else {
+			value, err := customFieldValue(fieldType, field.SecretValue.ValueString())
+			if err != nil {
+				return nil, nil, fmt.Errorf("custom field %q: %w", key, err)
+			}
+			secretField["secret_value"] = value
+		}
+
+		metadataFields = append(metadataFields, metadataField)
+		secretFields = append(secretFields, secretField)
+	}
+
+	return metadataFields, secretFields, nil
+}
+
+// customFieldValue converts a configured value to the JSON type Passbolt stores for fieldType.
+func customFieldValue(fieldType, value string) (any, error) {
+	switch fieldType {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "number":
+		return strconv.ParseFloat(value, 64)
+	default:
+		return value, nil
+	}
+}
+
+func customFieldString(value any) types.String {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.StringValue(strconv.FormatBool(v))
+	case float64:
+		return types.StringValue(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return types.StringValue(fmt.Sprint(v))
+	}
+}
+
+// readPasswordURIs returns the URIs of a v5 resource, or the single URI of a v4 resource.
+func readPasswordURIs(metadataFields map[string]any) []string {
+	rawURIs, ok := metadataFields["uris"].([]any)
+	if !ok {
+		return []string{helper.GetStringField(metadataFields, "uri")}
+	}
+
+	uris := make([]string, 0, len(rawURIs))
+	for _, raw := range rawURIs {
+		if uri, ok := raw.(string); ok {
+			uris = append(uris, uri)
+		}
+	}
+
+	return uris
+}
+
+func readPasswordIcon(metadataFields map[string]any) (types.Int64, types.String) {
+	icon, _ := metadataFields["icon"].(map[string]any)
+
+	iconValue := types.Int64Null()
+	if value, ok := icon["value"].(float64); ok {
+		iconValue = types.Int64Value(int64(value))
+	}
+
+	color := types.StringNull()
+	if value, ok := icon["background_color"].(string); ok && value != "" {
+		color = types.StringValue(value)
+	}
+
+	return iconValue, color
+}
+
+// readPasswordCustomFields joins the metadata and secret halves of custom fields by ID. Passbolt clients may keep a
+// key in either half, so both are checked.
+func readPasswordCustomFields(metadataFields, secretFields map[string]any) map[string]passwordCustomFieldModel {
+	metadataEntries := customFieldEntries(metadataFields)
+	if len(metadataEntries) == 0 {
+		return nil
+	}
+
+	secretEntries := make(map[string]map[string]any)
+	for _, entry := range customFieldEntries(secretFields) {
+		id, _ := entry["id"].(string)
+		secretEntries[id] = entry
+	}
+
+	fields := make(map[string]passwordCustomFieldModel, len(metadataEntries))
+	for _, entry := range metadataEntries {
+		id, _ := entry["id"].(string)
+		secretEntry := secretEntries[id]
+
+		key := helper.GetStringField(entry, "metadata_key")
+		if key == "" {
+			key = helper.GetStringField(secretEntry, "secret_key")
+		}
+
+		fields[key] = passwordCustomFieldModel{
+			Type:        types.StringValue(helper.GetStringField(entry, "type")),
+			Value:       customFieldString(entry["metadata_value"]),
+			SecretValue: customFieldString(secretEntry["secret_value"]),
+		}
+	}
+
+	return fields
+}
+
+func customFieldEntries(fields map[string]any) []map[string]any {
+	rawEntries, _ := fields["custom_fields"].([]any)
+
+	entries := make([]map[string]any, 0, len(rawEntries))
+	for _, raw := range rawEntries {
+		if entry, ok := raw.(map[string]any); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// applyPasswordFieldsState sets uri or uris, icon, color, and custom fields from the decrypted resource. uris is
+// used when the configuration uses it, or on import when the resource has several URIs.
+func applyPasswordFieldsState(
+	state *passwordModel,
+	existing passwordModel,
+	metadataFields map[string]any,
+	secretFields map[string]any,
+) {
+	uris := readPasswordURIs(metadataFields)
+	if existing.URIs != nil || (existing.URI.IsNull() && len(uris) > 1) {
+		state.URI = types.StringNull()
+		state.URIs = make([]types.String, 0, len(uris))
+		for _, uri := range uris {
+			state.URIs = append(state.URIs, types.StringValue(uri))
+		}
+	} else {
+		state.URI = types.StringValue(helper.GetStringField(metadataFields, "uri"))
+		state.URIs = nil
+	}
+
+	state.Icon, state.Color = readPasswordIcon(metadataFields)
+	state.CustomFields = readPasswordCustomFields(metadataFields, secretFields)
+}
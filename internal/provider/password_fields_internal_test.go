@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPasswordCustomFieldsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]passwordCustomFieldModel{
+		"region": {
+			Type:        types.StringValue("text"),
+			Value:       types.StringValue("eu-west-1"),
+			SecretValue: types.StringNull(),
+		},
+		"api_token": {
+			Type:        types.StringValue("password"),
+			Value:       types.StringNull(),
+			SecretValue: types.StringValue("s3cr3t"),
+		},
+		"replicas": {
+			Type:        types.StringValue("number"),
+			Value:       types.StringValue("3"),
+			SecretValue: types.StringNull(),
+		},
+	}
+
+	metadataCustomFields, secretCustomFields, err := passwordCustomFieldMaps(fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range metadataCustomFields {
+		if metadataCustomFields[i]["id"] != secretCustomFields[i]["id"] {
+			t.Fatalf("metadata and secret halves of field %d have different ids", i)
+		}
+	}
+
+	metadataFields := decodeFieldMap(t, map[string]any{"custom_fields": metadataCustomFields})
+	secretFields := decodeFieldMap(t, map[string]any{"custom_fields": secretCustomFields})
+
+	got := readPasswordCustomFields(metadataFields, secretFields)
+	if len(got) != len(fields) {
+		t.Fatalf("expected %d custom fields, got %#v", len(fields), got)
+	}
+
+	for key, want := range fields {
+		if !got[key].Type.Equal(want.Type) || !got[key].Value.Equal(want.Value) ||
+			!got[key].SecretValue.Equal(want.SecretValue) {
+			t.Fatalf("custom field %q: expected %#v, got %#v", key, want, got[key])
+		}
+	}
+}
+
+func TestPasswordCustomFieldIDsAreStable(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]passwordCustomFieldModel{
+		"region": {Type: types.StringValue("text"), Value: types.StringValue("eu-west-1")},
+	}
+
+	first, _, err := passwordCustomFieldMaps(fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	second, _, err := passwordCustomFieldMaps(fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first[0]["id"] != second[0]["id"] {
+		t.Fatalf("expected stable ids, got %v and %v", first[0]["id"], second[0]["id"])
+	}
+}
+
+func TestPasswordCustomFieldRejectsInvalidTypedValues(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"boolean": "maybe",
+		"number":  "three",
+	}
+
+	for fieldType, value := range tests {
+		t.Run(fieldType, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := passwordCustomFieldMaps(map[string]passwordCustomFieldModel{
+				"field": {Type: types.StringValue(fieldType), Value: types.StringValue(value)},
+			})
+			if err == nil {
+				t.Fatalf("expected %q to be rejected for %s fields", value, fieldType)
+			}
+		})
+	}
+}
+
+func TestPasswordExtraFieldsResetRemovedIconAndCustomFields(t *testing.T) {
+	t.Parallel()
+
+	state := passwordModel{
+		URI:   types.StringValue("https://db.example.com"),
+		Icon:  types.Int64Value(12),
+		Color: types.StringValue("#112233"),
+		CustomFields: map[string]passwordCustomFieldModel{
+			"region": {Type: types.StringValue("text"), Value: types.StringValue("eu-west-1")},
+		},
+	}
+	plan := passwordModel{URI: types.StringValue("https://db.example.com")}
+
+	extra, err := passwordExtraFieldsFrom(plan, state)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(extra.Icon) != 1 || extra.Icon["type"] != passwordIconSet {
+		t.Fatalf("expected the icon to be reset to the default, got %#v", extra.Icon)
+	}
+	if extra.MetadataCustomFields == nil || len(extra.MetadataCustomFields) != 0 {
+		t.Fatalf("expected custom fields to be cleared, got %#v", extra.MetadataCustomFields)
+	}
+	if err := extra.checkMetadataType(metadataTypeV4); err != nil {
+		t.Fatalf("resetting appearance should not require v5 metadata: %v", err)
+	}
+}
+
+func TestPasswordExtraFieldsRequireV5(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]passwordModel{
+		"several uris": {
+			URIs: []types.String{types.StringValue("https://a.example.com"), types.StringValue("https://b.example.com")},
+		},
+		"icon": {
+			URI:  types.StringValue("https://a.example.com"),
+			Icon: types.Int64Value(3),
+		},
+		"custom fields": {
+			URI: types.StringValue("https://a.example.com"),
+			CustomFields: map[string]passwordCustomFieldModel{
+				"region": {Type: types.StringValue("text"), Value: types.StringValue("eu-west-1")},
+			},
+		},
+	}
+
+	for name, plan := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			extra, err := passwordExtraFieldsFrom(plan, passwordModel{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := extra.checkMetadataType(metadataTypeV4); err == nil {
+				t.Fatal("expected v4 metadata to be rejected")
+			}
+			if err := extra.checkMetadataType(metadataTypeV5); err != nil {
+				t.Fatalf("expected v5 metadata to be accepted: %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyPasswordFieldsStateChoosesURIAttribute(t *testing.T) {
+	t.Parallel()
+
+	metadataFields := map[string]any{
+		"uri":  "https://a.example.com",
+		"uris": []any{"https://a.example.com", "https://b.example.com"},
+		"icon": map[string]any{"type": passwordIconSet, "value": float64(7), "background_color": "#AABBCC"},
+	}
+
+	tests := map[string]struct {
+		existing passwordModel
+		wantURIs int
+	}{
+		"configured uri": {
+			existing: passwordModel{URI: types.StringValue("https://a.example.com")},
+		},
+		"configured uris": {
+			existing: passwordModel{URIs: []types.String{types.StringValue("https://a.example.com")}},
+			wantURIs: 2,
+		},
+		"import": {
+			existing: passwordModel{URI: types.StringNull()},
+			wantURIs: 2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var state passwordModel
+			applyPasswordFieldsState(&state, tt.existing, metadataFields, nil)
+
+			if len(state.URIs) != tt.wantURIs {
+				t.Fatalf("expected %d uris, got %#v", tt.wantURIs, state.URIs)
+			}
+			if tt.wantURIs == 0 && state.URI.ValueString() != "https://a.example.com" {
+				t.Fatalf("expected uri to be set, got %s", state.URI)
+			}
+			if state.Icon.ValueInt64() != 7 || state.Color.ValueString() != "#AABBCC" {
+				t.Fatalf("unexpected icon state: %s %s", state.Icon, state.Color)
+			}
+		})
+	}
+}
+
+// decodeFieldMap round-trips fields through JSON, as decrypted Passbolt metadata and secrets are.
+func decodeFieldMap(t *testing.T, fields map[string]any) map[string]any {
+	t.Helper()
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("failed to encode fields: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode fields: %v", err)
+	}
+
+	return decoded
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const passwordWOFingerprintPrivateKey = "password_wo_fingerprint"
+
+// passwordWODriftVersion replaces password_wo_version in state when the remote secret no longer matches the last
+// applied password_wo. The configured version then differs, so the next plan writes password_wo again.
+const passwordWODriftVersion = 0
+
+type passwordPrivateState interface {
+	passwordPrivateStateReader
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// passwordFingerprint is a salted HMAC-SHA256 of the last applied write-only secret, kept in private state so Read
+// can notice secrets changed outside of Terraform without the plaintext ever being persisted.
+type passwordFingerprint struct {
+	Salt string `json:"salt"`
+	Hash string `json:"hash"`
+}
+
+func newPasswordFingerprint(secret string) (passwordFingerprint, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return passwordFingerprint{}, fmt.Errorf("generating fingerprint salt: %w", err)
+	}
+
+	return passwordFingerprint{
+		Salt: base64.StdEncoding.EncodeToString(salt),
+		Hash: base64.StdEncoding.EncodeToString(passwordFingerprintHash(salt, secret)),
+	}, nil
+}
+
+func (f passwordFingerprint) matches(secret string) bool {
+	salt, err := base64.StdEncoding.DecodeString(f.Salt)
+	if err != nil {
+		return false
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(f.Hash)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(hash, passwordFingerprintHash(salt, secret))
+}
+
+func passwordFingerprintHash(salt []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(secret))
+
+	return mac.Sum(nil)
+}
+
+// setPasswordFingerprint records secret as the last applied write-only secret.
+func setPasswordFingerprint(ctx context.Context, privateState passwordPrivateState, secret string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	fingerprint, err := newPasswordFingerprint(secret)
+	if err != nil {
+		diags.AddError("Cannot fingerprint password", err.Error())
+
+		return diags
+	}
+
+	value, err := json.Marshal(fingerprint)
+	if err != nil {
+		diags.AddError("Cannot fingerprint password", err.Error())
+
+		return diags
+	}
+
+	return privateState.SetKey(ctx, passwordWOFingerprintPrivateKey, value)
+}
+
+func readPasswordFingerprint(
+	ctx context.Context,
+	privateState passwordPrivateStateReader,
+	diags *diag.Diagnostics,
+) (passwordFingerprint, bool) {
+	value, privateDiags := privateState.GetKey(ctx, passwordWOFingerprintPrivateKey)
+	diags.Append(privateDiags...)
+	if privateDiags.HasError() || len(value) == 0 {
+		return passwordFingerprint{}, false
+	}
+
+	var fingerprint passwordFingerprint
+	if err := json.Unmarshal(value, &fingerprint); err != nil {
+		// A corrupt fingerprint only disables drift detection until the next rotation.
+		return passwordFingerprint{}, false
+	}
+
+	return fingerprint, true
+}
+
+// checkWriteOnlyPasswordDrift compares the remote secret with the fingerprint of the last applied password_wo.
+// Resources without a fingerprint, such as ones created by earlier provider versions, adopt the current secret.
+func checkWriteOnlyPasswordDrift(
+	ctx context.Context,
+	privateState passwordPrivateState,
+	state *passwordModel,
+	actualPassword string,
+	diags *diag.Diagnostics,
+) {
+	fingerprint, ok := readPasswordFingerprint(ctx, privateState, diags)
+	if diags.HasError() {
+		return
+	}
+
+	if !ok {
+		diags.Append(setPasswordFingerprint(ctx, privateState, actualPassword)...)
+
+		return
+	}
+
+	if fingerprint.matches(actualPassword) {
+		return
+	}
+
+	diags.AddWarning(
+		"Password changed outside of Terraform",
+		fmt.Sprintf(
+			"The secret of password %s no longer matches the last value applied through password_wo. "+
+				"The next apply writes password_wo again.",
+			state.ID.ValueString(),
+		),
+	)
+	state.PasswordWOVersion = types.Int64Value(passwordWODriftVersion)
+}
+
+// updatePasswordFingerprint keeps the private fingerprint in line with the secret Terraform last wrote.
+func updatePasswordFingerprint(
+	ctx context.Context,
+	privateState passwordPrivateState,
+	config passwordModel,
+	state passwordModel,
+) diag.Diagnostics {
+	// A migrated secret was not written by Terraform, so the next Read fingerprints the secret it finds.
+	if !hasWriteOnlyPasswordConfig(config) || migratesToWriteOnlyPassword(config, state) {
+		return privateState.SetKey(ctx, passwordWOFingerprintPrivateKey, nil)
+	}
+
+	if !passwordWOVersionChanged(config, state) {
+		return nil
+	}
+
+	return setPasswordFingerprint(ctx, privateState, config.PasswordWO.ValueString())
+}
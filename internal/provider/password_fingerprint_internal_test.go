@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type fakePasswordPrivateState map[string][]byte
+
+func (f fakePasswordPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return f[key], nil
+}
+
+func (f fakePasswordPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if value == nil {
+		delete(f, key)
+	} else {
+		f[key] = value
+	}
+
+	return nil
+}
+
+func TestPasswordFingerprintMatches(t *testing.T) {
+	t.Parallel()
+
+	first, err := newPasswordFingerprint("s3cret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := newPasswordFingerprint("s3cret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !first.matches("s3cret") || first.matches("other") {
+		t.Fatal("expected the fingerprint to match only the fingerprinted secret")
+	}
+	if first.Hash == second.Hash {
+		t.Fatal("expected fingerprints of the same secret to differ by salt")
+	}
+}
+
+func TestCheckWriteOnlyPasswordDrift(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	privateState := fakePasswordPrivateState{}
+	if diags := setPasswordFingerprint(ctx, privateState, "applied"); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if strings.Contains(string(privateState[passwordWOFingerprintPrivateKey]), "applied") {
+		t.Fatal("expected the private state to hold no plaintext")
+	}
+
+	state := passwordModel{ID: types.StringValue("resource-id"), PasswordWOVersion: types.Int64Value(3)}
+
+	var diags diag.Diagnostics
+	checkWriteOnlyPasswordDrift(ctx, privateState, &state, "applied", &diags)
+	if len(diags) != 0 || state.PasswordWOVersion.ValueInt64() != 3 {
+		t.Fatalf("expected no drift, got %v and version %s", diags, state.PasswordWOVersion)
+	}
+
+	checkWriteOnlyPasswordDrift(ctx, privateState, &state, "changed in the UI", &diags)
+	if len(diags.Warnings()) != 1 || diags.HasError() {
+		t.Fatalf("expected a drift warning, got %v", diags)
+	}
+	if state.PasswordWOVersion.ValueInt64() != passwordWODriftVersion {
+		t.Fatalf("expected password_wo_version to be reset, got %s", state.PasswordWOVersion)
+	}
+}
+
+func TestCheckWriteOnlyPasswordDriftAdoptsSecretWithoutFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	privateState := fakePasswordPrivateState{}
+	state := passwordModel{ID: types.StringValue("resource-id"), PasswordWOVersion: types.Int64Value(1)}
+
+	var diags diag.Diagnostics
+	checkWriteOnlyPasswordDrift(ctx, privateState, &state, "current", &diags)
+	if len(diags) != 0 || state.PasswordWOVersion.ValueInt64() != 1 {
+		t.Fatalf("expected the current secret to be adopted, got %v", diags)
+	}
+
+	fingerprint, ok := readPasswordFingerprint(ctx, privateState, &diags)
+	if !ok || !fingerprint.matches("current") {
+		t.Fatal("expected a fingerprint of the current secret")
+	}
+}
+
+func TestUpdatePasswordFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	privateState := fakePasswordPrivateState{}
+	state := passwordModel{PasswordWOVersion: types.Int64Value(1)}
+	config := passwordModel{PasswordWO: types.StringValue("rotated"), PasswordWOVersion: types.Int64Value(1)}
+
+	updatePasswordFingerprint(ctx, privateState, config, state)
+	if _, ok := privateState[passwordWOFingerprintPrivateKey]; ok {
+		t.Fatal("expected no fingerprint while the version is unchanged")
+	}
+
+	config.PasswordWOVersion = types.Int64Value(2)
+	updatePasswordFingerprint(ctx, privateState, config, state)
+
+	var diags diag.Diagnostics
+	if fingerprint, ok := readPasswordFingerprint(ctx, privateState, &diags); !ok || !fingerprint.matches("rotated") {
+		t.Fatal("expected a fingerprint of the rotated secret")
+	}
+
+	updatePasswordFingerprint(ctx, privateState, passwordModel{Password: types.StringValue("legacy")}, state)
+	if _, ok := privateState[passwordWOFingerprintPrivateKey]; ok {
+		t.Fatal("expected the fingerprint to be cleared when password_wo is no longer used")
+	}
+}
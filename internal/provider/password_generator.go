@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	passwordGeneratorDefaultLength = 32
+	passwordGeneratorMinLength     = 8
+	passwordGeneratorMaxLength     = 128
+
+	passwordGeneratorLowercase = "abcdefghijklmnopqrstuvwxyz"
+	passwordGeneratorUppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordGeneratorNumbers   = "0123456789"
+	passwordGeneratorLookAlike = "O0oIl1|"
+)
+
+// passwordGeneratorSpecialGroups mirrors the special character groups of the Passbolt password generator.
+var passwordGeneratorSpecialGroups = []string{
+	"{([|])}",
+	"#$%&@^~",
+	".,:;",
+	"'\"`",
+	"/\\_-",
+	"<*+!?=",
+}
+
+type passwordGenerateModel struct {
+	Length            types.Int64  `tfsdk:"length"`
+	Lowercase         types.Bool   `tfsdk:"lowercase"`
+	Uppercase         types.Bool   `tfsdk:"uppercase"`
+	Numbers           types.Bool   `tfsdk:"numbers"`
+	Special           types.Bool   `tfsdk:"special"`
+	ExcludeCharacters types.String `tfsdk:"exclude_characters"`
+	ExcludeLookAlike  types.Bool   `tfsdk:"exclude_look_alike"`
+	Keepers           types.Map    `tfsdk:"keepers"`
+}
+
+type passwordGeneratorSettings struct {
+	Length            int
+	Lowercase         bool
+	Uppercase         bool
+	Numbers           bool
+	Special           string
+	ExcludeCharacters string
+	ExcludeLookAlike  bool
+}
+
+type passwordPolicySettings struct {
+	DefaultGenerator          string                           `json:"default_generator"`
+	PasswordGeneratorSettings *passwordGeneratorPolicySettings `json:"password_generator_settings"`
+}
+
+type passwordGeneratorPolicySettings struct {
+	Length                int  `json:"length"`
+	MaskUpper             bool `json:"mask_upper"`
+	MaskLower             bool `json:"mask_lower"`
+	MaskDigit             bool `json:"mask_digit"`
+	MaskParenthesis       bool `json:"mask_parenthesis"`
+	MaskChar1             bool `json:"mask_char1"`
+	MaskChar2             bool `json:"mask_char2"`
+	MaskChar3             bool `json:"mask_char3"`
+	MaskChar4             bool `json:"mask_char4"`
+	MaskChar5             bool `json:"mask_char5"`
+	ExcludeLookAlikeChars bool `json:"exclude_look_alike_chars"`
+}
+
+func generatePasswordFromConfig(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	config *passwordGenerateModel,
+) (string, error) {
+	settings := defaultPasswordGeneratorSettings()
+
+	policy, err := getPassboltPasswordPolicy(ctx, client)
+	if err != nil {
+		tflog.Debug(ctx, "Password policy unavailable, using built-in generator defaults", map[string]any{
+			"error": err.Error(),
+		})
+	} else {
+		settings = applyPasswordPolicySettings(settings, policy)
+	}
+
+	return generatePassword(applyPasswordGenerateConfig(settings, config))
+}
+
+func getPassboltPasswordPolicy(
+	ctx context.Context,
+	client *tools.PassboltClient,
+) (*passwordGeneratorPolicySettings, error) {
+	msg, err := client.Client.DoCustomRequestV5(ctx, "GET", "/password-policies/settings.json", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting password policy settings: %w", err)
+	}
+
+	var policy passwordPolicySettings
+	if err := json.Unmarshal(msg.Body, &policy); err != nil {
+		return nil, fmt.Errorf("parsing password policy settings: %w", err)
+	}
+
+	if policy.PasswordGeneratorSettings == nil {
+		return nil, fmt.Errorf("password policy settings have no password generator settings")
+	}
+
+	return policy.PasswordGeneratorSettings, nil
+}
+
+func defaultPasswordGeneratorSettings() passwordGeneratorSettings {
+	return passwordGeneratorSettings{
+		Length:    passwordGeneratorDefaultLength,
+		Lowercase: true,
+		Uppercase: true,
+		Numbers:   true,
+		Special:   strings.Join(passwordGeneratorSpecialGroups, ""),
+	}
+}
+
+func applyPasswordPolicySettings(
+	settings passwordGeneratorSettings,
+	policy *passwordGeneratorPolicySettings,
+) passwordGeneratorSettings {
+	if policy.Length >= passwordGeneratorMinLength {
+		settings.Length = policy.Length
+	}
+
+	settings.Lowercase = policy.MaskLower
+	settings.Uppercase = policy.MaskUpper
+	settings.Numbers = policy.MaskDigit
+	settings.ExcludeLookAlike = policy.ExcludeLookAlikeChars
+
+	masks := []bool{
+		policy.MaskParenthesis,
+		policy.MaskChar1,
+		policy.MaskChar2,
+		policy.MaskChar3,
+		policy.MaskChar4,
+		policy.MaskChar5,
+	}
+
+	var special strings.Builder
+	for i, enabled := range masks {
+		if enabled {
+			special.WriteString(passwordGeneratorSpecialGroups[i])
+		}
+	}
+	settings.Special = special.String()
+
+	return settings
+}
+
+func applyPasswordGenerateConfig(
+	settings passwordGeneratorSettings,
+	config *passwordGenerateModel,
+) passwordGeneratorSettings {
+	if config == nil {
+		return settings
+	}
+
+	if !config.Length.IsNull() && !config.Length.IsUnknown() {
+		settings.Length = int(config.Length.ValueInt64())
+	}
+	if !config.Lowercase.IsNull() && !config.Lowercase.IsUnknown() {
+		settings.Lowercase = config.Lowercase.ValueBool()
+	}
+	if !config.Uppercase.IsNull() && !config.Uppercase.IsUnknown() {
+		settings.Uppercase = config.Uppercase.ValueBool()
+	}
+	if !config.Numbers.IsNull() && !config.Numbers.IsUnknown() {
+		settings.Numbers = config.Numbers.ValueBool()
+	}
+	if !config.Special.IsNull() && !config.Special.IsUnknown() {
+		settings.Special = ""
+		if config.Special.ValueBool() {
+			settings.Special = strings.Join(passwordGeneratorSpecialGroups, "")
+		}
+	}
+	if !config.ExcludeCharacters.IsNull() && !config.ExcludeCharacters.IsUnknown() {
+		settings.ExcludeCharacters = config.ExcludeCharacters.ValueString()
+	}
+	if !config.ExcludeLookAlike.IsNull() && !config.ExcludeLookAlike.IsUnknown() {
+		settings.ExcludeLookAlike = config.ExcludeLookAlike.ValueBool()
+	}
+
+	return settings
+}
+
+func (s passwordGeneratorSettings) characterClasses() []string {
+	excluded := s.ExcludeCharacters
+	if s.ExcludeLookAlike {
+		excluded += passwordGeneratorLookAlike
+	}
+
+	candidates := make([]string, 0, 4)
+	if s.Lowercase {
+		candidates = append(candidates, passwordGeneratorLowercase)
+	}
+	if s.Uppercase {
+		candidates = append(candidates, passwordGeneratorUppercase)
+	}
+	if s.Numbers {
+		candidates = append(candidates, passwordGeneratorNumbers)
+	}
+	if s.Special != "" {
+		candidates = append(candidates, s.Special)
+	}
+
+	classes := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		class := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(excluded, r) {
+				return -1
+			}
+
+			return r
+		}, candidate)
+		if class != "" {
+			classes = append(classes, class)
+		}
+	}
+
+	return classes
+}
+
+func generatePassword(settings passwordGeneratorSettings) (string, error) {
+	classes := settings.characterClasses()
+	if len(classes) == 0 {
+		return "", fmt.Errorf("password generator has no characters left to choose from")
+	}
+
+	if settings.Length < len(classes) {
+		return "", fmt.Errorf(
+			"password length %d is too short to include all %d enabled character classes",
+			settings.Length,
+			len(classes),
+		)
+	}
+
+	password := make([]rune, 0, settings.Length)
+	for _, class := range classes {
+		char, err := randomRune(class)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, char)
+	}
+
+	all := strings.Join(classes, "")
+	for len(password) < settings.Length {
+		char, err := randomRune(all)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, char)
+	}
+
+	if err := shuffleRunes(password); err != nil {
+		return "", err
+	}
+
+	return string(password), nil
+}
+
+func randomRune(charset string) (rune, error) {
+	runes := []rune(charset)
+
+	index, err := randomIndex(len(runes))
+	if err != nil {
+		return 0, err
+	}
+
+	return runes[index], nil
+}
+
+func shuffleRunes(values []rune) error {
+	for i := len(values) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return err
+		}
+
+		values[i], values[j] = values[j], values[i]
+	}
+
+	return nil
+}
+
+func randomIndex(size int) (int, error) {
+	value, err := rand.Int(rand.Reader, big.NewInt(int64(size)))
+	if err != nil {
+		return 0, fmt.Errorf("generating random password: %w", err)
+	}
+
+	return int(value.Int64()), nil
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGeneratePasswordIncludesEveryEnabledClass(t *testing.T) {
+	t.Parallel()
+
+	settings := defaultPasswordGeneratorSettings()
+	settings.Length = 12
+
+	for range 50 {
+		password, err := generatePassword(settings)
+		if err != nil {
+			t.Fatalf("unexpected generator error: %v", err)
+		}
+
+		if got := len([]rune(password)); got != 12 {
+			t.Fatalf("expected 12 characters, got %d", got)
+		}
+
+		for _, class := range settings.characterClasses() {
+			if !strings.ContainsAny(password, class) {
+				t.Fatalf("expected %q to contain one of %q", password, class)
+			}
+		}
+	}
+}
+
+func TestGeneratePasswordHonorsExclusions(t *testing.T) {
+	t.Parallel()
+
+	settings := passwordGeneratorSettings{
+		Length:            64,
+		Lowercase:         true,
+		Numbers:           true,
+		ExcludeCharacters: "abc",
+		ExcludeLookAlike:  true,
+	}
+
+	password, err := generatePassword(settings)
+	if err != nil {
+		t.Fatalf("unexpected generator error: %v", err)
+	}
+
+	if strings.ContainsAny(password, "abc"+passwordGeneratorLookAlike) {
+		t.Fatalf("expected %q to omit excluded characters", password)
+	}
+
+	if strings.ContainsAny(password, passwordGeneratorUppercase) {
+		t.Fatalf("expected %q to omit disabled uppercase letters", password)
+	}
+}
+
+func TestGeneratePasswordRejectsImpossibleSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		settings passwordGeneratorSettings
+		wantErr  string
+	}{
+		"no classes": {
+			settings: passwordGeneratorSettings{Length: 16},
+			wantErr:  "no characters left",
+		},
+		"everything excluded": {
+			settings: passwordGeneratorSettings{
+				Length:            16,
+				Numbers:           true,
+				ExcludeCharacters: passwordGeneratorNumbers,
+			},
+			wantErr: "no characters left",
+		},
+		"too short for classes": {
+			settings: passwordGeneratorSettings{
+				Length:    3,
+				Lowercase: true,
+				Uppercase: true,
+				Numbers:   true,
+				Special:   "!",
+			},
+			wantErr: "too short",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := generatePassword(test.settings)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestApplyPasswordPolicySettings(t *testing.T) {
+	t.Parallel()
+
+	settings := applyPasswordPolicySettings(defaultPasswordGeneratorSettings(), &passwordGeneratorPolicySettings{
+		Length:                20,
+		MaskLower:             true,
+		MaskDigit:             true,
+		MaskChar1:             true,
+		ExcludeLookAlikeChars: true,
+	})
+
+	if settings.Length != 20 {
+		t.Fatalf("expected policy length 20, got %d", settings.Length)
+	}
+	if !settings.Lowercase || settings.Uppercase || !settings.Numbers {
+		t.Fatalf("expected policy character classes, got %+v", settings)
+	}
+	if settings.Special != passwordGeneratorSpecialGroups[1] {
+		t.Fatalf("expected only the first special character group, got %q", settings.Special)
+	}
+	if !settings.ExcludeLookAlike {
+		t.Fatal("expected policy to exclude look-alike characters")
+	}
+}
+
+func TestApplyPasswordGenerateConfigOverridesPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := passwordGeneratorSettings{Length: 20, Lowercase: true, Special: "#"}
+	settings := applyPasswordGenerateConfig(policy, &passwordGenerateModel{
+		Length:            types.Int64Value(40),
+		Lowercase:         types.BoolNull(),
+		Uppercase:         types.BoolValue(true),
+		Numbers:           types.BoolNull(),
+		Special:           types.BoolValue(false),
+		ExcludeCharacters: types.StringValue("x"),
+		ExcludeLookAlike:  types.BoolNull(),
+		Keepers:           types.MapNull(types.StringType),
+	})
+
+	if settings.Length != 40 || !settings.Lowercase || !settings.Uppercase || settings.Special != "" {
+		t.Fatalf("expected configured values to override policy, got %+v", settings)
+	}
+	if settings.ExcludeCharacters != "x" {
+		t.Fatalf("expected excluded characters %q, got %q", "x", settings.ExcludeCharacters)
+	}
+}
+
+func TestGeneratedPasswordRotationRequired(t *testing.T) {
+	t.Parallel()
+
+	keepers := func(value string) *passwordGenerateModel {
+		return &passwordGenerateModel{
+			Keepers: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"rotation": types.StringValue(value),
+			}),
+		}
+	}
+
+	tests := map[string]struct {
+		config   passwordModel
+		state    passwordModel
+		imported bool
+		want     bool
+	}{
+		"not generated": {
+			config: passwordModel{},
+			state:  passwordModel{Generate: keepers("1")},
+		},
+		"unchanged keepers": {
+			config: passwordModel{Generate: keepers("1")},
+			state:  passwordModel{Generate: keepers("1")},
+		},
+		"changed keepers": {
+			config: passwordModel{Generate: keepers("2")},
+			state:  passwordModel{Generate: keepers("1")},
+			want:   true,
+		},
+		"switch to generate": {
+			config: passwordModel{Generate: keepers("1")},
+			state:  passwordModel{},
+			want:   true,
+		},
+		"adopt imported secret": {
+			config:   passwordModel{Generate: keepers("1")},
+			state:    passwordModel{},
+			imported: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := generatedPasswordRotationRequired(test.config, test.state, test.imported)
+			if got != test.want {
+				t.Fatalf("expected rotation %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildPasswordStateSecretsKeepsGeneratedSecretOutOfState(t *testing.T) {
+	t.Parallel()
+
+	password, passwordWO, passwordWOVersion := buildPasswordStateSecrets(
+		passwordModel{
+			Password:          types.StringNull(),
+			PasswordWOVersion: types.Int64Null(),
+			Generate:          &passwordGenerateModel{},
+		},
+		"generated-secret",
+		false,
+	)
+
+	if !password.IsNull() || !passwordWO.IsNull() || !passwordWOVersion.IsNull() {
+		t.Fatalf("expected generated secret to stay out of state, got %v %v %v", password, passwordWO, passwordWOVersion)
+	}
+}
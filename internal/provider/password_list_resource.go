@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &passwordListResource{}
+	_ list.ListResourceWithConfigure = &passwordListResource{}
+)
+
+// NewPasswordListResource returns a list resource that enumerates Passbolt passwords for terraform query.
+func NewPasswordListResource() list.ListResource {
+	return &passwordListResource{}
+}
+
+type passwordListResource struct {
+	client *tools.PassboltClient
+}
+
+type passwordListResourceModel struct {
+	FolderPath types.String `tfsdk:"folder_path"`
+	Recursive  types.Bool   `tfsdk:"recursive"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+func (r *passwordListResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	r.client = configureListResourceClient(req, resp)
+}
+
+func (r *passwordListResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_password"
+}
+
+func (r *passwordListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists Passbolt passwords visible to the user, sorted by folder path and name. Secrets are " +
+			"never decrypted.",
+		Attributes: map[string]schema.Attribute{
+			"folder_path": schema.StringAttribute{
+				Optional: true,
+				Description: "Only list passwords in this folder. Accepts a unique folder name, a folder UUID, or an " +
+					"absolute path such as `/application_A/prod`. Leave unset to list every password.",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "With `folder_path`, also list passwords in nested subfolders. Defaults to `false`.",
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list passwords whose name starts with this prefix.",
+			},
+		},
+	}
+}
+
+func (r *passwordListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	diags := ensureListLogin(ctx, r.client)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	var config passwordListResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	folders, err := getCachedPassboltFolders(ctx, r.client)
+	if err != nil {
+		diags.AddError("Cannot get folders", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	pathsByID, err := buildFolderPathIndex(folders)
+	if err != nil {
+		diags.AddError("Unable to build folder paths", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	var opts *api.GetResourcesOptions
+	if config.FolderPath.ValueString() != "" {
+		folderID, err := resolveFolderReferenceValue(folders, config.FolderPath.ValueString())
+		if err != nil {
+			diags.AddError("Invalid folder reference", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+			return
+		}
+
+		opts = &api.GetResourcesOptions{FilterHasParent: []string{folderID}}
+		if config.Recursive.ValueBool() {
+			opts.FilterHasParent = folderSubtreeIDs(folders, folderID)
+		}
+	}
+
+	resources, err := r.client.Client.GetResources(ctx, opts)
+	if err != nil {
+		diags.AddError("Unable to Read resources", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	entries, err := decryptPassboltPasswords(ctx, r.client, resources, false, nil)
+	if err != nil {
+		diags.AddError("Unable to Read resources", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	stream.Results = streamListItems(ctx, req, passwordListItems(entries, pathsByID, config.NamePrefix))
+}
+
+func passwordListItems(
+	entries []passboltPasswordEntry,
+	pathsByID map[string]string,
+	namePrefix types.String,
+) []listItem {
+	items := make([]listItem, 0, len(entries))
+	for _, entry := range entries {
+		if !listNameMatches(entry.Name, namePrefix) {
+			continue
+		}
+
+		items = append(items, listItem{
+			ID:          entry.ID,
+			DisplayName: pathsByID[entry.FolderParentID] + "/" + entry.Name,
+			Attributes: map[string]any{
+				"name":          entry.Name,
+				"username":      entry.Username,
+				"uri":           pickOptional(entry.URI),
+				"description":   pickOptional(entry.Description),
+				"folder_parent": pickOptional(entry.FolderParentID),
+			},
+		})
+	}
+
+	slices.SortFunc(items, func(a, b listItem) int {
+		return cmp.Or(cmp.Compare(a.DisplayName, b.DisplayName), cmp.Compare(a.ID, b.ID))
+	})
+
+	return items
+}
@@ -26,6 +26,7 @@ var (
 	_ resource.ResourceWithConfigure        = &passwordPermissionResource{}
 	_ resource.ResourceWithConfigValidators = &passwordPermissionResource{}
 	_ resource.ResourceWithImportState      = &passwordPermissionResource{}
+	_ resource.ResourceWithIdentity         = &passwordPermissionResource{}
 )
 
 const (
@@ -55,7 +56,7 @@ type passwordPermissionModel struct {
 	Permission types.String `tfsdk:"permission"`
 }
 
-type passwordPermissionTarget struct {
+type permissionTarget struct {
 	ARO  string
 	ID   string
 	Kind string
@@ -115,7 +116,8 @@ func (r *passwordPermissionResource) Schema(
 			"id": schema.StringAttribute{
 				Computed: true,
 				Description: "Internal resource ID in the format `resource_id:group:group_name` or " +
-					"`resource_id:user:username`.",
+					"`resource_id:user:username`. The resource identity records the group or user UUID instead, so " +
+					"renaming the group or user in Passbolt does not orphan the permission.",
 			},
 			"resource_id": schema.StringAttribute{
 				Required:    true,
@@ -151,11 +153,25 @@ func (r *passwordPermissionResource) Schema(
 	}
 }
 
+func (r *passwordPermissionResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = permissionIdentitySchema("resource_id", "The UUID of the Passbolt password/resource.")
+}
+
 func (r *passwordPermissionResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	if req.ID == "" {
+		r.importStateFromIdentity(ctx, req, resp)
+
+		return
+	}
+
 	importID, err := parsePasswordPermissionImportID(req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid import ID format", err.Error())
@@ -173,18 +189,54 @@ func (r *passwordPermissionResource) ImportState(
 	}
 }
 
+func (r *passwordPermissionResource) importStateFromIdentity(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var identity passwordPermissionIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := permissionTargetByID(ctx, r.client, identity.permissionPrincipalIdentity)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identity", err.Error())
+
+		return
+	}
+
+	state := passwordPermissionModel{
+		ResourceID: identity.ResourceID,
+		GroupName:  types.StringNull(),
+		Username:   types.StringNull(),
+		Permission: types.StringNull(),
+	}
+	state.setTarget(target)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
 func (r *passwordPermissionResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan passwordPermissionModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan, err := r.applyPasswordPermissionState(ctx, plan)
+	plan, target, err := r.applyPasswordPermissionState(ctx, plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot share password", err.Error())
 
@@ -192,22 +244,36 @@ func (r *passwordPermissionResource) Create(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newPasswordPermissionIdentity(plan, target))...)
 }
 
 func (r *passwordPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state passwordPermissionModel
+	var identity passwordPermissionIdentityModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(getIdentity(ctx, req.Identity, &identity)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	target, err := resolvePasswordPermissionTarget(ctx, r.client, state, false)
+	target, err := r.currentTarget(ctx, identity, state)
+	if errors.Is(err, errPermissionPrincipalNotFound) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot resolve permission target", err.Error())
 
 		return
 	}
 
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newPasswordPermissionIdentity(state, target))...)
+
 	permission, err := readPasswordPermission(ctx, r.client, state.ResourceID.ValueString(), target)
 	if err != nil {
 		if errors.Is(err, errPasswordPermissionNotFound) {
@@ -221,7 +287,7 @@ func (r *passwordPermissionResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	state.ID = types.StringValue(passwordPermissionID(state.ResourceID.ValueString(), target.Kind, target.Name))
+	state.setTarget(target)
 	state.Permission = types.StringValue(permission)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -231,13 +297,17 @@ func (r *passwordPermissionResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan passwordPermissionModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	plan, err := r.applyPasswordPermissionState(ctx, plan)
+	plan, target, err := r.applyPasswordPermissionState(ctx, plan)
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot update password permission", err.Error())
 
@@ -245,6 +315,7 @@ func (r *passwordPermissionResource) Update(
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, newPasswordPermissionIdentity(plan, target))...)
 }
 
 func (r *passwordPermissionResource) Delete(
@@ -252,13 +323,22 @@ func (r *passwordPermissionResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state passwordPermissionModel
+	var identity passwordPermissionIdentityModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(getIdentity(ctx, req.Identity, &identity)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	target, err := resolvePasswordPermissionTarget(ctx, r.client, state, false)
+	target, err := r.currentTarget(ctx, identity, state)
+	if errors.Is(err, errPermissionPrincipalNotFound) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot resolve permission target", err.Error())
 
@@ -273,20 +353,55 @@ func (r *passwordPermissionResource) Delete(
 func (r *passwordPermissionResource) applyPasswordPermissionState(
 	ctx context.Context,
 	plan passwordPermissionModel,
-) (passwordPermissionModel, error) {
+) (passwordPermissionModel, permissionTarget, error) {
 	target, err := resolvePasswordPermissionTarget(ctx, r.client, plan, true)
 	if err != nil {
-		return plan, fmt.Errorf("resolving permission target: %w", err)
+		return plan, target, fmt.Errorf("resolving permission target: %w", err)
 	}
 
 	err = applyPasswordPermission(ctx, r.client, plan.ResourceID.ValueString(), target, plan.Permission.ValueString())
 	if err != nil {
-		return plan, err
+		return plan, target, err
 	}
 
 	plan.ID = types.StringValue(passwordPermissionID(plan.ResourceID.ValueString(), target.Kind, target.Name))
 
-	return plan, nil
+	return plan, target, nil
+}
+
+// currentTarget resolves the group or user of a permission that is already in state.
+func (r *passwordPermissionResource) currentTarget(
+	ctx context.Context,
+	identity passwordPermissionIdentityModel,
+	state passwordPermissionModel,
+) (permissionTarget, error) {
+	return currentPermissionTarget(ctx, r.client, identity.permissionPrincipalIdentity, func() (permissionTarget, error) {
+		return resolvePasswordPermissionTarget(ctx, r.client, state, false)
+	})
+}
+
+// setTarget points the model at target. A name that no longer matches, because the group or user was renamed in
+// Passbolt, is replaced by the current one.
+func (m *passwordPermissionModel) setTarget(target permissionTarget) {
+	name := &m.GroupName
+	if target.Kind == passwordPermissionImportKindUser {
+		name = &m.Username
+	}
+	if !target.matchesName(name.ValueString()) {
+		*name = types.StringValue(target.Name)
+	}
+
+	m.ID = types.StringValue(passwordPermissionID(m.ResourceID.ValueString(), target.Kind, name.ValueString()))
+}
+
+func newPasswordPermissionIdentity(
+	model passwordPermissionModel,
+	target permissionTarget,
+) passwordPermissionIdentityModel {
+	return passwordPermissionIdentityModel{
+		ResourceID:                  model.ResourceID,
+		permissionPrincipalIdentity: newPermissionPrincipalIdentity(target),
+	}
 }
 
 func resolvePasswordPermissionTarget(
@@ -294,35 +409,47 @@ func resolvePasswordPermissionTarget(
 	client *tools.PassboltClient,
 	model passwordPermissionModel,
 	requireActiveUser bool,
-) (passwordPermissionTarget, error) {
-	if !model.GroupName.IsNull() && !model.GroupName.IsUnknown() && model.GroupName.ValueString() != "" {
-		groupID, err := getgroupIDByName(ctx, client, model.GroupName.ValueString())
+) (permissionTarget, error) {
+	return resolvePermissionTarget(ctx, client, model.GroupName, model.Username, requireActiveUser)
+}
+
+// resolvePermissionTarget resolves the group or user named in a permission resource. Exactly one of groupName and
+// username must be set.
+func resolvePermissionTarget(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	groupName types.String,
+	username types.String,
+	requireActiveUser bool,
+) (permissionTarget, error) {
+	if !groupName.IsNull() && !groupName.IsUnknown() && groupName.ValueString() != "" {
+		groupID, err := getgroupIDByName(ctx, client, groupName.ValueString())
 		if err != nil {
-			return passwordPermissionTarget{}, err
+			return permissionTarget{}, err
 		}
 
-		return passwordPermissionTarget{
+		return permissionTarget{
 			ARO:  passwordPermissionAROGroup,
 			ID:   groupID,
 			Kind: passwordPermissionImportKindGroup,
-			Name: model.GroupName.ValueString(),
+			Name: groupName.ValueString(),
 		}, nil
 	}
 
-	if model.Username.IsNull() || model.Username.IsUnknown() || model.Username.ValueString() == "" {
-		return passwordPermissionTarget{}, errors.New("exactly one of group_name or username must be set")
+	if username.IsNull() || username.IsUnknown() || username.ValueString() == "" {
+		return permissionTarget{}, errors.New("exactly one of group_name or username must be set")
 	}
 
-	user, err := getUserByUsername(ctx, client, model.Username.ValueString(), !requireActiveUser)
+	user, err := getUserByUsername(ctx, client, username.ValueString(), !requireActiveUser)
 	if err != nil {
-		return passwordPermissionTarget{}, err
+		return permissionTarget{}, err
 	}
 
-	return passwordPermissionTarget{
+	return permissionTarget{
 		ARO:  passwordPermissionAROUser,
 		ID:   user.ID,
 		Kind: passwordPermissionImportKindUser,
-		Name: model.Username.ValueString(),
+		Name: username.ValueString(),
 	}, nil
 }
 
@@ -332,9 +459,7 @@ func getUserByUsername(
 	username string,
 	includeInactive bool,
 ) (*api.User, error) {
-	users, err := client.Client.GetUsers(ctx, &api.GetUsersOptions{
-		FilterSearch: username,
-	})
+	users, err := client.Users(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
@@ -346,7 +471,7 @@ func applyPasswordPermission(
 	ctx context.Context,
 	client *tools.PassboltClient,
 	resourceID string,
-	target passwordPermissionTarget,
+	target permissionTarget,
 	permission string,
 ) error {
 	permissionType, err := passwordPermissionStringToInt(permission)
@@ -369,7 +494,7 @@ func revokePasswordPermission(
 	ctx context.Context,
 	client *tools.PassboltClient,
 	resourceID string,
-	target passwordPermissionTarget,
+	target permissionTarget,
 ) error {
 	_, err := readPasswordPermission(ctx, client, resourceID, target)
 	if errors.Is(err, errPasswordPermissionNotFound) {
@@ -386,25 +511,28 @@ func sharePasswordPermission(
 	ctx context.Context,
 	client *tools.PassboltClient,
 	resourceID string,
-	target passwordPermissionTarget,
+	target permissionTarget,
 	permissionType int,
 ) error {
-	users := []string(nil)
-	groups := []string(nil)
-	if target.ARO == passwordPermissionAROUser {
-		users = []string{target.ID}
-	} else {
-		groups = []string{target.ID}
-	}
+	users, groups := target.shareLists()
 
 	return helper.ShareResourceWithUsersAndGroups(ctx, client.Client, resourceID, users, groups, permissionType)
 }
 
+// shareLists returns the user and group ID lists that share with the target in the go-passbolt helpers.
+func (t permissionTarget) shareLists() ([]string, []string) {
+	if t.ARO == passwordPermissionAROUser {
+		return []string{t.ID}, nil
+	}
+
+	return nil, []string{t.ID}
+}
+
 func readPasswordPermission(
 	ctx context.Context,
 	client *tools.PassboltClient,
 	resourceID string,
-	target passwordPermissionTarget,
+	target permissionTarget,
 ) (string, error) {
 	permissions, err := client.Client.GetResourcePermissions(ctx, resourceID)
 	if err != nil {
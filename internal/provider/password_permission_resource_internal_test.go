@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestPasswordPermissionStringToInt(t *testing.T) {
@@ -214,3 +215,107 @@ func TestPasswordPermissionIdentityAttributesRequireReplace(t *testing.T) {
 		}
 	}
 }
+
+func TestPasswordPermissionModelSetTargetFollowsRenames(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model        passwordPermissionModel
+		target       permissionTarget
+		wantGroup    types.String
+		wantUsername types.String
+		wantID       string
+	}{
+		"renamed group": {
+			model: passwordPermissionModel{
+				ResourceID: types.StringValue("resource-id"),
+				GroupName:  types.StringValue("Developers"),
+				Username:   types.StringNull(),
+			},
+			target:       permissionTarget{Kind: passwordPermissionImportKindGroup, ID: "group-id", Name: "Engineering"},
+			wantGroup:    types.StringValue("Engineering"),
+			wantUsername: types.StringNull(),
+			wantID:       "resource-id:group:Engineering",
+		},
+		"username in other case": {
+			model: passwordPermissionModel{
+				ResourceID: types.StringValue("resource-id"),
+				GroupName:  types.StringNull(),
+				Username:   types.StringValue("Dev@Example.com"),
+			},
+			target:       permissionTarget{Kind: passwordPermissionImportKindUser, ID: "user-id", Name: "dev@example.com"},
+			wantGroup:    types.StringNull(),
+			wantUsername: types.StringValue("Dev@Example.com"),
+			wantID:       "resource-id:user:Dev@Example.com",
+		},
+		"imported user": {
+			model: passwordPermissionModel{
+				ResourceID: types.StringValue("resource-id"),
+				GroupName:  types.StringNull(),
+				Username:   types.StringNull(),
+			},
+			target:       permissionTarget{Kind: passwordPermissionImportKindUser, ID: "user-id", Name: "dev@example.com"},
+			wantGroup:    types.StringNull(),
+			wantUsername: types.StringValue("dev@example.com"),
+			wantID:       "resource-id:user:dev@example.com",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := test.model
+			model.setTarget(test.target)
+			if !model.GroupName.Equal(test.wantGroup) || !model.Username.Equal(test.wantUsername) {
+				t.Fatalf("expected %s and %s, got %s and %s", test.wantGroup, test.wantUsername, model.GroupName, model.Username)
+			}
+			if model.ID.ValueString() != test.wantID {
+				t.Fatalf("expected id %q, got %q", test.wantID, model.ID.ValueString())
+			}
+		})
+	}
+}
+
+func TestPermissionTargetByIDRejectsUnknownPrincipalType(t *testing.T) {
+	t.Parallel()
+
+	_, err := permissionTargetByID(context.Background(), nil, permissionPrincipalIdentity{
+		PrincipalType: types.StringValue("team"),
+		PrincipalID:   types.StringValue("team-id"),
+	})
+	if err == nil || errors.Is(err, errPermissionPrincipalNotFound) {
+		t.Fatalf("expected an invalid principal type error, got %v", err)
+	}
+}
+
+func TestPermissionIdentitySchemas(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resource  resource.Resource
+		objectKey string
+	}{
+		"password permission": {resource: NewPasswordPermissionResource(), objectKey: "resource_id"},
+		"folder permission":   {resource: NewFolderPermissionResource(), objectKey: "folder_id"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp resource.IdentitySchemaResponse
+			test.resource.(resource.ResourceWithIdentity).IdentitySchema(
+				context.Background(),
+				resource.IdentitySchemaRequest{},
+				&resp,
+			)
+
+			for _, attribute := range []string{test.objectKey, "principal_type", "principal_id"} {
+				if _, ok := resp.IdentitySchema.Attributes[attribute]; !ok {
+					t.Fatalf("expected %s in the identity schema", attribute)
+				}
+			}
+		})
+	}
+}
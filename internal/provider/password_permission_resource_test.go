@@ -8,7 +8,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 )
@@ -57,6 +61,52 @@ func TestAccPasswordPermissionResource_group(t *testing.T) {
 	})
 }
 
+func TestAccPasswordPermissionResource_importByIdentity(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE", "PASSBOLT_MANAGER_ID")
+
+	suffix := testAccSuffix()
+	config := testPasswordGroupPermissionConfig(
+		os.Getenv("PASSBOLT_BASE_URL"),
+		os.Getenv("PASSBOLT_PRIVATE_KEY"),
+		os.Getenv("PASSBOLT_PASSPHRASE"),
+		os.Getenv("PASSBOLT_MANAGER_ID"),
+		testAccName("acc-password-permission-identity", suffix),
+		testAccName("acc-password-permission-identity", suffix),
+		"update",
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState(
+						"passbolt_password_permission.group",
+						tfjsonpath.New("resource_id"),
+					),
+					statecheck.ExpectIdentityValue(
+						"passbolt_password_permission.group",
+						tfjsonpath.New("principal_type"),
+						knownvalue.StringExact("group"),
+					),
+				},
+			},
+			{
+				Config:          config,
+				ResourceName:    "passbolt_password_permission.group",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 func TestAccPasswordPermissionResource_user(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure implementation
+var (
+	_ resource.Resource                   = &passwordPermissionsResource{}
+	_ resource.ResourceWithConfigure      = &passwordPermissionsResource{}
+	_ resource.ResourceWithValidateConfig = &passwordPermissionsResource{}
+	_ resource.ResourceWithImportState    = &passwordPermissionsResource{}
+	_ resource.ResourceWithIdentity       = &passwordPermissionsResource{}
+)
+
+// NewPasswordPermissionsResource returns a Terraform resource that manages every permission of a Passbolt password.
+func NewPasswordPermissionsResource() resource.Resource {
+	return &passwordPermissionsResource{}
+}
+
+type passwordPermissionsResource struct {
+	client *tools.PassboltClient
+}
+
+type passwordPermissionsModel struct {
+	ID          types.String           `tfsdk:"id"`
+	ResourceID  types.String           `tfsdk:"resource_id"`
+	Permissions []permissionEntryModel `tfsdk:"permissions"`
+}
+
+func (r *passwordPermissionsResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PassboltClient, got: %T.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *passwordPermissionsResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_password_permissions"
+}
+
+func (r *passwordPermissionsResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete list of groups and users with access to a Passbolt password. Grants " +
+			"that are not in `permissions`, including grants added in the Passbolt UI, are revoked on apply and " +
+			"show up as drift until then. Do not combine it with `passbolt_password_permission` or the " +
+			"`share_groups` arguments of `passbolt_password` on the same password.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The UUID of the password.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The UUID of the Passbolt password whose permissions are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": permissionEntriesAttribute("password"),
+		},
+	}
+}
+
+func (r *passwordPermissionsResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	resp.Diagnostics.Append(validatePermissionEntriesConfig(ctx, req.Config)...)
+}
+
+func (r *passwordPermissionsResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the password.")
+}
+
+func (r *passwordPermissionsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	importByIDOrIdentity(ctx, req, resp, func(id string) (string, diag.Diagnostics) {
+		return resolvePasswordImportID(ctx, r.client, id)
+	})
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var resourceID types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &resourceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_id"), resourceID)...)
+}
+
+func (r *passwordPermissionsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan passwordPermissionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyPasswordPermissions(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("Cannot share password", err.Error())
+
+		return
+	}
+
+	plan.ID = plan.ResourceID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *passwordPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var state passwordPermissionsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
+	permissions, err := r.client.Client.GetResourcePermissions(ctx, state.ResourceID.ValueString())
+	if err != nil && isNotFoundError(err) {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading password permissions", err.Error())
+
+		return
+	}
+
+	entries, err := readPermissionEntries(ctx, r.client, permissions, state.Permissions)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read password permissions", err.Error())
+
+		return
+	}
+
+	state.ID = state.ResourceID
+	state.Permissions = entries
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *passwordPermissionsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	var plan passwordPermissionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.applyPasswordPermissions(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("Cannot update password permissions", err.Error())
+
+		return
+	}
+
+	plan.ID = plan.ResourceID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+// Delete stops managing the password's permissions. Revoking every grant would leave the password without an owner,
+// so the permissions are left as they are.
+func (r *passwordPermissionsResource) Delete(
+	_ context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+}
+
+// applyPasswordPermissions makes the password's permissions match plan in one share request.
+func (r *passwordPermissionsResource) applyPasswordPermissions(
+	ctx context.Context,
+	plan passwordPermissionsModel,
+) error {
+	desired, err := resolveDesiredPermissions(ctx, r.client, plan.Permissions)
+	if err != nil {
+		return err
+	}
+
+	current, err := r.client.Client.GetResourcePermissions(ctx, plan.ResourceID.ValueString())
+	if err != nil {
+		return fmt.Errorf("getting resource permissions: %w", err)
+	}
+
+	changes, err := permissionChanges(current, desired)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return helper.ShareResource(ctx, r.client.Client, plan.ResourceID.ValueString(), changes)
+}
@@ -0,0 +1,128 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPasswordPermissionsResource_basic(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(
+		t,
+		"PASSBOLT_BASE_URL",
+		"PASSBOLT_PRIVATE_KEY",
+		"PASSBOLT_PASSPHRASE",
+		"PASSBOLT_MANAGER_ID",
+		"PASSBOLT_TEST_USER_EMAIL",
+	)
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	managerID := os.Getenv("PASSBOLT_MANAGER_ID")
+	username := os.Getenv("PASSBOLT_TEST_USER_EMAIL")
+	suffix := testAccSuffix()
+	groupName := testAccName("acl-owners", suffix)
+	passwordName := testAccName("acl-password", suffix)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPasswordPermissionsConfig(
+					baseURL, privateKey, passphrase, managerID, groupName, passwordName, username, "read",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"passbolt_password_permissions.acl", "id",
+						"passbolt_password.shared", "id",
+					),
+					resource.TestCheckResourceAttr("passbolt_password_permissions.acl", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("passbolt_password_permissions.acl", "permissions.*", map[string]string{
+						"group_name": groupName,
+						"permission": "owner",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("passbolt_password_permissions.acl", "permissions.*", map[string]string{
+						"username":   username,
+						"permission": "read",
+					}),
+				),
+			},
+			{
+				Config: testPasswordPermissionsConfig(
+					baseURL, privateKey, passphrase, managerID, groupName, passwordName, username, "update",
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_password_permissions.acl", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("passbolt_password_permissions.acl", "permissions.*", map[string]string{
+						"username":   username,
+						"permission": "update",
+					}),
+				),
+			},
+			{
+				ResourceName:      "passbolt_password_permissions.acl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "passbolt_password_permissions.acl",
+				ImportState:       true,
+				ImportStateId:     "/" + passwordName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testPasswordPermissionsConfig(
+	baseURL,
+	privateKey,
+	passphrase,
+	managerID,
+	groupName,
+	passwordName,
+	username,
+	permission string,
+) string {
+	return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_group" "owners" {
+  name     = "%s"
+  managers = ["%s"]
+}
+
+resource "passbolt_password" "shared" {
+  name     = "%s"
+  username = "password-permissions-user"
+  uri      = "https://password-permissions.example.com"
+  password = "password-permissions-secret"
+}
+
+resource "passbolt_password_permissions" "acl" {
+  resource_id = passbolt_password.shared.id
+
+  permissions = [
+    {
+      group_name = passbolt_group.owners.name
+      permission = "owner"
+    },
+    {
+      username   = "%s"
+      permission = "%s"
+    },
+  ]
+}
+`, baseURL, privateKey, passphrase, groupName, managerID, passwordName, username, permission)
+}
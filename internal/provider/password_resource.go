@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"terraform-provider-passbolt/tools"
 	"time"
 
 	"github.com/ProtonMail/gopenpgp/v3/crypto"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -26,6 +29,7 @@ var (
 	_ resource.ResourceWithConfigure        = &passwordResource{}
 	_ resource.ResourceWithConfigValidators = &passwordResource{}
 	_ resource.ResourceWithImportState      = &passwordResource{}
+	_ resource.ResourceWithIdentity         = &passwordResource{}
 )
 
 // NewPasswordResource returns a new instance of passwordResource as a Terraform resource.
@@ -44,19 +48,28 @@ type passwordPrivateStateReader interface {
 }
 
 type passwordModel struct {
-	ID                 types.String   `tfsdk:"id"`
-	Name               types.String   `tfsdk:"name"`
-	Description        types.String   `tfsdk:"description"`
-	Username           types.String   `tfsdk:"username"`
-	URI                types.String   `tfsdk:"uri"`
-	ShareGroup         types.String   `tfsdk:"share_group"`
-	ShareGroups        []types.String `tfsdk:"share_groups"`
-	FolderParent       types.String   `tfsdk:"folder_parent"`
-	Password           types.String   `tfsdk:"password"`
-	PasswordWO         types.String   `tfsdk:"password_wo"`
-	PasswordWOVersion  types.Int64    `tfsdk:"password_wo_version"`
-	MetadataType       types.String   `tfsdk:"metadata_type"`
-	MetadataTypeActual types.String   `tfsdk:"metadata_type_actual"`
+	ID                 types.String                        `tfsdk:"id"`
+	Name               types.String                        `tfsdk:"name"`
+	Description        types.String                        `tfsdk:"description"`
+	Username           types.String                        `tfsdk:"username"`
+	URI                types.String                        `tfsdk:"uri"`
+	URIs               []types.String                      `tfsdk:"uris"`
+	Icon               types.Int64                         `tfsdk:"icon"`
+	Color              types.String                        `tfsdk:"color"`
+	CustomFields       map[string]passwordCustomFieldModel `tfsdk:"custom_fields"`
+	ShareGroup         types.String                        `tfsdk:"share_group"`
+	ShareGroups        []types.String                      `tfsdk:"share_groups"`
+	ShareGroupPerms    map[string]types.String             `tfsdk:"share_group_permissions"`
+	ShareAuthoritative types.Bool                          `tfsdk:"share_groups_authoritative"`
+	FolderParent       types.String                        `tfsdk:"folder_parent"`
+	Password           types.String                        `tfsdk:"password"`
+	PasswordWO         types.String                        `tfsdk:"password_wo"`
+	PasswordWOVersion  types.Int64                         `tfsdk:"password_wo_version"`
+	PasswordWOMigrate  types.Bool                          `tfsdk:"password_wo_migrate"`
+	MetadataType       types.String                        `tfsdk:"metadata_type"`
+	MetadataTypeActual types.String                        `tfsdk:"metadata_type_actual"`
+	Generate           *passwordGenerateModel              `tfsdk:"generate"`
+	TOTP               *passwordTOTPModel                  `tfsdk:"totp"`
 }
 
 type passwordStringUpdateRequest struct {
@@ -105,10 +118,24 @@ func (r *passwordResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	importByIDOrIdentity(ctx, req, resp, func(id string) (string, diag.Diagnostics) {
+		return resolvePasswordImportID(ctx, r.client, id)
+	})
 	resp.Diagnostics.Append(resp.Private.SetKey(ctx, passwordImportSecretModeUnknownPrivateKey, []byte(`true`))...)
 }
 
+func (r *passwordResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the Passbolt password.")
+}
+
 func (r *passwordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_password"
 }
@@ -118,11 +145,16 @@ func (r *passwordResource) ConfigValidators(_ context.Context) []resource.Config
 		resourcevalidator.ExactlyOneOf(
 			path.MatchRoot("password"),
 			path.MatchRoot("password_wo"),
+			path.MatchRoot("generate"),
 		),
 		resourcevalidator.RequiredTogether(
 			path.MatchRoot("password_wo"),
 			path.MatchRoot("password_wo_version"),
 		),
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("uri"),
+			path.MatchRoot("uris"),
+		),
 	}
 }
 
@@ -130,8 +162,9 @@ func (r *passwordResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		Description: "Manages a secret/password entry in Passbolt. Supports optional folder placement, group sharing, " +
 			"Passbolt v4 and v5 encrypted metadata resources, and both legacy stateful passwords and write-only " +
-			"password workflows.",
+			"password workflows, and can generate the secret itself with keepers-driven rotation.",
 		Attributes: passwordResourceSchemaAttributes(),
+		Blocks:     passwordResourceSchemaBlocks(),
 	}
 }
 
@@ -140,6 +173,7 @@ func passwordResourceSchemaAttributes() map[string]schema.Attribute {
 		passwordResourceCoreAttributes(),
 		passwordResourceSharingAttributes(),
 		passwordResourceSecretAttributes(),
+		passwordResourceV5Attributes(),
 	)
 }
 
@@ -162,8 +196,9 @@ func passwordResourceCoreAttributes() map[string]schema.Attribute {
 			Description: "Username or login for the password/secret entry.",
 		},
 		"uri": schema.StringAttribute{
-			Required:    true,
-			Description: "The URI or URL where the secret is used (e.g., https://service.example.com).",
+			Optional: true,
+			Description: "The URI or URL where the secret is used (e.g., https://service.example.com). Exactly one " +
+				"of `uri` or `uris` must be set.",
 		},
 		"folder_parent": schema.StringAttribute{
 			Optional:    true,
@@ -198,6 +233,23 @@ func passwordResourceSharingAttributes() map[string]schema.Attribute {
 			Description: "List of Passbolt group names to share this secret with. Supports multiple group " +
 				"shares. Takes precedence over `share_group`.",
 		},
+		"share_group_permissions": schema.MapAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: "Map of Passbolt group names to permission levels: `read`, `update`, or `owner`. " +
+				"Groups listed in `share_groups` or `share_group` get `update` unless overridden here. " +
+				"Groups that only appear in this map are shared with the given level as well.",
+			Validators: []validator.Map{
+				mapvalidator.ValueStringsAre(stringvalidator.OneOf("read", "update", "owner")),
+			},
+		},
+		"share_groups_authoritative": schema.BoolAttribute{
+			Optional: true,
+			Description: "When `true`, group sharing is authoritative: groups that are not configured through " +
+				"`share_groups`, `share_group`, or `share_group_permissions` lose access to the secret, and " +
+				"Terraform reports the actual group grants so drift shows up in plans. Direct user grants " +
+				"are left untouched. Defaults to `false`, which only adds or adjusts configured grants.",
+		},
 	}
 }
 
@@ -215,7 +267,9 @@ func passwordResourceSecretAttributes() map[string]schema.Attribute {
 			Sensitive: true,
 			WriteOnly: true,
 			Description: "Write-only secret input. Terraform does not persist this value in plan or state files. " +
-				"Set `password_wo_version` and increment it whenever you want to rotate the secret.",
+				"Set `password_wo_version` and increment it whenever you want to rotate the secret. A salted " +
+				"fingerprint of the applied secret is kept in private state, so a secret changed outside of " +
+				"Terraform is reported as a warning and rewritten on the next apply.",
 		},
 		"password_wo_version": schema.Int64Attribute{
 			Optional: true,
@@ -225,6 +279,63 @@ func passwordResourceSecretAttributes() map[string]schema.Attribute {
 				int64validator.AtLeast(1),
 			},
 		},
+		"password_wo_migrate": schema.BoolAttribute{
+			Optional: true,
+			Description: "Keeps the current Passbolt secret when an existing password switches from `password`, " +
+				"`generate`, or an import to `password_wo`. The plaintext is removed from state and " +
+				"`password_wo_version` is recorded without writing `password_wo`. Later version changes rotate " +
+				"the secret as usual.",
+		},
+	}
+}
+
+func passwordResourceSchemaBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"generate": schema.SingleNestedBlock{
+			Description: "Generates the secret inside the provider instead of taking it from configuration. " +
+				"The generated value is never stored in Terraform state. Unset settings follow the Passbolt " +
+				"server's password generator policy when available. The secret is only rotated when `keepers` " +
+				"change; other setting changes apply on the next rotation.",
+			Attributes: map[string]schema.Attribute{
+				"length": schema.Int64Attribute{
+					Optional:    true,
+					Description: "Length of the generated secret. Defaults to the server policy, or 32.",
+					Validators: []validator.Int64{
+						int64validator.Between(passwordGeneratorMinLength, passwordGeneratorMaxLength),
+					},
+				},
+				"lowercase": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Include lowercase letters.",
+				},
+				"uppercase": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Include uppercase letters.",
+				},
+				"numbers": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Include digits.",
+				},
+				"special": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Include special characters.",
+				},
+				"exclude_characters": schema.StringAttribute{
+					Optional:    true,
+					Description: "Characters that must never appear in the generated secret.",
+				},
+				"exclude_look_alike": schema.BoolAttribute{
+					Optional:    true,
+					Description: "Exclude look-alike characters such as `O`, `0`, `l`, `1`, and `|`.",
+				},
+				"keepers": schema.MapAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+					Description: "Arbitrary map of values that, when changed, rotate the generated secret.",
+				},
+			},
+		},
+		"totp": passwordTOTPSchemaBlock(),
 	}
 }
 
@@ -241,6 +352,10 @@ func mergePasswordAttributes(groups ...map[string]schema.Attribute) map[string]s
 }
 
 func (r *passwordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var config passwordModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -259,16 +374,30 @@ func (r *passwordResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	password, err := configuredPasswordForCreate(ctx, r.client, config)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot generate password", err.Error())
+
+		return
+	}
+
+	extra, err := passwordExtraFieldsFrom(plan, passwordModel{})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid custom_fields", err.Error())
+
+		return
+	}
+
 	resourceID, metadataTypeActual, err := createPassboltPasswordResource(
 		ctx,
 		r.client,
 		folderID,
 		plan.Name.ValueString(),
 		plan.Username.ValueString(),
-		plan.URI.ValueString(),
-		configuredPassword(config),
+		password,
 		plan.Description.ValueString(),
 		desiredMetadataType(plan.MetadataType),
+		extra,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError("Cannot create resource", err.Error())
@@ -279,14 +408,12 @@ func (r *passwordResource) Create(ctx context.Context, req resource.CreateReques
 	plan.ID = types.StringValue(resourceID)
 	plan.MetadataTypeActual = types.StringValue(metadataTypeActual)
 
-	if len(plan.ShareGroups) > 0 {
-		shareResourceWithGroups(ctx, r.client, plan.ShareGroups, resourceID, &resp.Diagnostics)
-	} else if !plan.ShareGroup.IsUnknown() && !plan.ShareGroup.IsNull() && plan.ShareGroup.ValueString() != "" {
-		shareResourceWithGroups(ctx, r.client, []types.String{plan.ShareGroup}, resourceID, &resp.Diagnostics)
-	}
+	shareResourceIfNeeded(ctx, r.client, plan, resourceID, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, buildManagedPasswordState(plan, config, types.StringValue(resourceID)))...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, types.StringValue(resourceID))...)
 	resp.Diagnostics.Append(resp.Private.SetKey(ctx, passwordImportSecretModeUnknownPrivateKey, nil)...)
+	resp.Diagnostics.Append(updatePasswordFingerprint(ctx, resp.Private, config, passwordModel{})...)
 }
 
 // resolveFolderId can now match both name and UUID
@@ -301,7 +428,7 @@ func resolveFolderID(
 	}
 
 	value := folder.ValueString()
-	folders, err := getPassboltFolders(ctx, client, nil)
+	folders, err := getCachedPassboltFolders(ctx, client)
 	if err != nil {
 		diags.AddError("Cannot get folders", err.Error())
 
@@ -325,39 +452,51 @@ func createPassboltPasswordResource(
 	folderID string,
 	name string,
 	username string,
-	uri string,
 	password string,
 	description string,
 	metadataType string,
+	extra passwordExtraFields,
 ) (string, string, error) {
 	actualType := passwordMetadataTypeForCreate(client, metadataType)
+	if err := extra.checkMetadataType(actualType); err != nil {
+		return "", actualType, err
+	}
+
+	metadataFields := map[string]any{
+		"name":     name,
+		"username": username,
+	}
+	secretFields := map[string]any{
+		"password":    password,
+		"description": description,
+	}
 
 	switch actualType {
 	case metadataTypeV5:
-		resourceID, err := helper.CreateResourceGeneric(ctx, client.Client, "v5-default", folderID,
-			map[string]any{
-				"name":     name,
-				"username": username,
-				"uris":     []string{uri},
-			},
-			map[string]any{
-				"password":    password,
-				"description": description,
-			},
+		metadataFields["uris"] = extra.URIs
+		extra.apply(metadataFields, secretFields, true)
+
+		resourceID, err := helper.CreateResourceGeneric(
+			ctx,
+			client.Client,
+			passwordResourceTypeSlug(actualType, extra.TOTP != nil),
+			folderID,
+			metadataFields,
+			secretFields,
 		)
 
 		return resourceID, actualType, err
 	case metadataTypeV4:
-		resourceID, err := helper.CreateResourceGeneric(ctx, client.Client, "password-and-description", folderID,
-			map[string]any{
-				"name":     name,
-				"username": username,
-				"uri":      uri,
-			},
-			map[string]any{
-				"password":    password,
-				"description": description,
-			},
+		metadataFields["uri"] = extra.URIs[0]
+		extra.apply(metadataFields, secretFields, false)
+
+		resourceID, err := helper.CreateResourceGeneric(
+			ctx,
+			client.Client,
+			passwordResourceTypeSlug(actualType, extra.TOTP != nil),
+			folderID,
+			metadataFields,
+			secretFields,
 		)
 
 		return resourceID, actualType, err
@@ -368,7 +507,7 @@ func createPassboltPasswordResource(
 			folderID,
 			name,
 			username,
-			uri,
+			extra.URIs[0],
 			password,
 			description,
 		)
@@ -389,14 +528,17 @@ func passwordMetadataTypeForCreate(client *tools.PassboltClient, metadataType st
 	return metadataType
 }
 
+// shareResourceWithGroups applies the desired group grants to a resource. When authoritative is set, groups
+// that are not part of grants are revoked as well.
 func shareResourceWithGroups(
 	ctx context.Context,
 	client *tools.PassboltClient,
-	groupNames []types.String,
+	grants map[string]int,
+	authoritative bool,
 	resourceID string,
 	diags *diag.Diagnostics,
 ) {
-	if len(groupNames) == 0 {
+	if len(grants) == 0 && !authoritative {
 		return
 	}
 
@@ -410,25 +552,44 @@ func shareResourceWithGroups(
 		return
 	}
 
-	changes := make([]helper.ShareOperation, 0, len(groupNames))
-	for _, name := range groupNames {
-		groupName := name.ValueString()
+	desiredIDs := make(map[string]struct{}, len(grants))
+	changes := make([]helper.ShareOperation, 0, len(grants))
+	for _, groupName := range slices.Sorted(maps.Keys(grants)) {
 		group, ok := groupsByName[groupName]
 		if !ok {
 			diags.AddError("Group not found", fmt.Sprintf("Group with name '%s' not found", groupName))
 
 			continue
 		}
-		if existingPerms[group.ID] == 7 {
+
+		desiredIDs[group.ID] = struct{}{}
+		if existingPerms[group.ID] == grants[groupName] {
 			continue
 		}
 		changes = append(changes, helper.ShareOperation{
-			Type:  7,
+			Type:  grants[groupName],
 			ARO:   "Group",
 			AROID: group.ID,
 		})
 	}
 
+	if authoritative {
+		for _, groupID := range slices.Sorted(maps.Keys(existingPerms)) {
+			if _, ok := desiredIDs[groupID]; ok {
+				continue
+			}
+			changes = append(changes, helper.ShareOperation{
+				Type:  -1,
+				ARO:   "Group",
+				AROID: groupID,
+			})
+		}
+	}
+
+	if diags.HasError() {
+		return
+	}
+
 	if len(changes) > 0 {
 		if err := helper.ShareResource(ctx, client.Client, resourceID, changes); err != nil {
 			diags.AddError("Cannot share resource", err.Error())
@@ -436,12 +597,55 @@ func shareResourceWithGroups(
 	}
 }
 
+// desiredPasswordGroupGrants returns the configured group permission types keyed by group name.
+func desiredPasswordGroupGrants(plan passwordModel) (map[string]int, error) {
+	grants := make(map[string]int)
+
+	for _, name := range configuredShareGroupNames(plan) {
+		grants[name] = 7
+	}
+
+	for name, permission := range plan.ShareGroupPerms {
+		if permission.IsNull() || permission.IsUnknown() {
+			continue
+		}
+
+		permissionType, err := passwordPermissionStringToInt(permission.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %w", name, err)
+		}
+		grants[name] = permissionType
+	}
+
+	return grants, nil
+}
+
+func configuredShareGroupNames(plan passwordModel) []string {
+	if len(plan.ShareGroups) > 0 {
+		names := make([]string, 0, len(plan.ShareGroups))
+		for _, name := range plan.ShareGroups {
+			if name.IsNull() || name.IsUnknown() {
+				continue
+			}
+			names = append(names, name.ValueString())
+		}
+
+		return names
+	}
+
+	if !plan.ShareGroup.IsUnknown() && !plan.ShareGroup.IsNull() && plan.ShareGroup.ValueString() != "" {
+		return []string{plan.ShareGroup.ValueString()}
+	}
+
+	return nil
+}
+
 func buildGroupNameMap(
 	ctx context.Context,
 	client *tools.PassboltClient,
 	diags *diag.Diagnostics,
 ) (map[string]api.Group, error) {
-	groups, err := client.Client.GetGroups(ctx, nil)
+	groups, err := client.Groups(ctx)
 	if err != nil {
 		diags.AddError("Cannot get groups", err.Error())
 
@@ -483,7 +687,7 @@ func buildPasswordState(
 	id string,
 	existing passwordModel,
 	importedSecretModeUnknown bool,
-) (passwordModel, diag.Diagnostics) {
+) (passwordModel, string, diag.Diagnostics) {
 	var state passwordModel
 	var diags diag.Diagnostics
 
@@ -491,22 +695,24 @@ func buildPasswordState(
 	if err != nil {
 		diags.AddError("Cannot read resource", err.Error())
 
-		return state, diags
+		return state, "", diags
 	}
 
-	folderID, name, username, uri, password, description, err := helper.GetResource(ctx, client.Client, id)
+	metadataFields, secretFields, err := readPassboltResourceFields(ctx, client, resourceData)
 	if err != nil {
 		diags.AddError("Cannot read resource", err.Error())
 
-		return state, diags
+		return state, "", diags
 	}
 
+	password := helper.GetStringField(secretFields, "password")
+
 	state.ID = types.StringValue(id)
-	state.Name = types.StringValue(name)
-	state.Username = types.StringValue(username)
-	state.URI = types.StringValue(uri)
-	state.Description = pickOptional(description)
-	state.FolderParent = pickOptional(folderID)
+	state.Name = types.StringValue(helper.GetStringField(metadataFields, "name"))
+	state.Username = types.StringValue(helper.GetStringField(metadataFields, "username"))
+	applyPasswordFieldsState(&state, existing, metadataFields, secretFields)
+	state.Description = pickOptional(helper.GetStringField(metadataFields, "description"))
+	state.FolderParent = pickOptional(resourceData.FolderParentID)
 	state.MetadataType = existing.MetadataType
 	state.MetadataTypeActual = types.StringValue(actualMetadataTypeFromEncryptedMetadata(resourceData.Metadata))
 	state.Password, state.PasswordWO, state.PasswordWOVersion = buildPasswordStateSecrets(
@@ -516,8 +722,29 @@ func buildPasswordState(
 	)
 	state.ShareGroup = buildPasswordStateShareGroup(existing)
 	state.ShareGroups = buildPasswordStateShareGroups(existing)
+	state.ShareGroupPerms = existing.ShareGroupPerms
+	state.ShareAuthoritative = existing.ShareAuthoritative
+	if existing.ShareAuthoritative.ValueBool() {
+		actualGrants, grantDiags := getActualGroupGrantsByName(ctx, client, id)
+		diags.Append(grantDiags...)
+		if diags.HasError() {
+			return state, "", diags
+		}
+
+		state.ShareGroup, state.ShareGroups, state.ShareGroupPerms = buildAuthoritativeShareState(existing, actualGrants)
+	}
+	state.PasswordWOMigrate = existing.PasswordWOMigrate
+	state.Generate = existing.Generate
 
-	return state, diags
+	totp, err := passwordTOTPFromSecretFields(secretFields)
+	if err != nil {
+		diags.AddError("Cannot read TOTP", err.Error())
+
+		return state, "", diags
+	}
+	state.TOTP = passwordTOTPModelFrom(totp)
+
+	return state, password, diags
 }
 
 func buildPasswordStateSecrets(
@@ -529,6 +756,10 @@ func buildPasswordStateSecrets(
 		return types.StringNull(), types.StringNull(), existing.PasswordWOVersion
 	}
 
+	if existing.Generate != nil {
+		return types.StringNull(), types.StringNull(), types.Int64Null()
+	}
+
 	if existing.Password.IsNull() || existing.Password.IsUnknown() {
 		if importedSecretModeUnknown {
 			return types.StringNull(), types.StringNull(), types.Int64Null()
@@ -540,6 +771,94 @@ func buildPasswordStateSecrets(
 	return pickPassword(actualPassword, existing.Password), types.StringNull(), types.Int64Null()
 }
 
+// getActualGroupGrantsByName returns the group permission types currently granted on a resource, keyed by group
+// name.
+func getActualGroupGrantsByName(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	resourceID string,
+) (map[string]int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	groupsByName, err := buildGroupNameMap(ctx, client, &diags)
+	if err != nil {
+		return nil, diags
+	}
+
+	existingPerms, err := getExistingGroupPermissions(ctx, client, resourceID, &diags)
+	if err != nil {
+		return nil, diags
+	}
+
+	grants := make(map[string]int, len(existingPerms))
+	for name, group := range groupsByName {
+		if permissionType, ok := existingPerms[group.ID]; ok {
+			grants[name] = permissionType
+		}
+	}
+
+	return grants, diags
+}
+
+// buildAuthoritativeShareState maps the actual group grants back onto the sharing attributes. Configured groups
+// keep their position, revoked groups disappear, and unmanaged grants are appended so that drift shows up in
+// the next plan.
+func buildAuthoritativeShareState(
+	existing passwordModel,
+	actual map[string]int,
+) (types.String, []types.String, map[string]types.String) {
+	shareGroup := types.StringNull()
+	var shareGroups []types.String
+	if existing.ShareGroups != nil {
+		shareGroups = []types.String{}
+	}
+
+	listed := make(map[string]struct{})
+	for _, name := range configuredShareGroupNames(existing) {
+		listed[name] = struct{}{}
+		if _, ok := actual[name]; !ok {
+			continue
+		}
+
+		if len(existing.ShareGroups) == 0 {
+			shareGroup = types.StringValue(name)
+
+			continue
+		}
+		shareGroups = append(shareGroups, types.StringValue(name))
+	}
+
+	var perms map[string]types.String
+	if existing.ShareGroupPerms != nil {
+		perms = map[string]types.String{}
+	}
+	setPerm := func(name string, permissionType int) {
+		if perms == nil {
+			perms = map[string]types.String{}
+		}
+		perms[name] = types.StringValue(passwordPermissionIntToString(permissionType))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(actual)) {
+		permissionType := actual[name]
+		_, isListed := listed[name]
+		_, hasPerm := existing.ShareGroupPerms[name]
+
+		switch {
+		case hasPerm:
+			setPerm(name, permissionType)
+		case isListed && permissionType != 7:
+			setPerm(name, permissionType)
+		case !isListed && permissionType == 7:
+			shareGroups = append(shareGroups, types.StringValue(name))
+		case !isListed:
+			setPerm(name, permissionType)
+		}
+	}
+
+	return shareGroup, shareGroups, perms
+}
+
 func buildPasswordStateShareGroup(existing passwordModel) types.String {
 	if existing.ShareGroup.IsUnknown() || existing.ShareGroup.IsNull() || existing.ShareGroup.ValueString() == "" {
 		return types.StringNull()
@@ -577,27 +896,50 @@ func pickOptional(value string) types.String {
 
 // Read retrieves the current state of the resource from Passbolt.
 func (r *passwordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state passwordModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
 	importedSecretModeUnknown := passwordImportSecretModeUnknown(ctx, req.Private, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	newState, diags := buildPasswordState(ctx, r.client, state.ID.ValueString(), state, importedSecretModeUnknown)
+	newState, actualPassword, diags := buildPasswordState(
+		ctx,
+		r.client,
+		state.ID.ValueString(),
+		state,
+		importedSecretModeUnknown,
+	)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if usesWriteOnlyPassword(newState) {
+		checkWriteOnlyPasswordDrift(ctx, resp.Private, &newState, actualPassword, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
 func (r *passwordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var config passwordModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
@@ -616,7 +958,13 @@ func (r *passwordResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	metadataTypeActual, diags := updateResourceFields(ctx, r, config, plan, state)
+	importedSecretModeUnknown := passwordImportSecretModeUnknown(ctx, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotateGenerated := generatedPasswordRotationRequired(config, state, importedSecretModeUnknown)
+	metadataTypeActual, diags := updateResourceFields(ctx, r, config, plan, state, rotateGenerated)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 
@@ -625,7 +973,9 @@ func (r *passwordResource) Update(ctx context.Context, req resource.UpdateReques
 
 	plan.MetadataTypeActual = types.StringValue(metadataTypeActual)
 	resp.Diagnostics.Append(resp.State.Set(ctx, buildManagedPasswordState(plan, config, state.ID))...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 	resp.Diagnostics.Append(resp.Private.SetKey(ctx, passwordImportSecretModeUnknownPrivateKey, nil)...)
+	resp.Diagnostics.Append(updatePasswordFingerprint(ctx, resp.Private, config, state)...)
 }
 
 func passwordImportSecretModeUnknown(
@@ -648,14 +998,49 @@ func updateResourceFields(
 	config passwordModel,
 	plan passwordModel,
 	state passwordModel,
+	rotateGenerated bool,
 ) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	passwordValue, descriptionValue, secretDiags := resolveSecretUpdateInputs(ctx, r.client, config, plan, state)
+	if migratesToWriteOnlyPassword(config, state) {
+		// Updating the resource re-encrypts and uploads its secret, so the migration only moves, shares, and
+		// changes state.
+		if passwordContentChanged(plan, state) {
+			diags.AddError(
+				"Cannot migrate to password_wo",
+				"password_wo_migrate leaves the secret in Passbolt untouched and cannot be combined with changes to "+
+					"the password's name, username, description, URIs, icon, custom fields, TOTP, or metadata type. "+
+					"Apply the migration on its own first.",
+			)
+
+			return "", diags
+		}
+
+		diags.Append(moveResourceIfNeeded(ctx, r.client, plan, state)...)
+		shareResourceIfNeeded(ctx, r.client, plan, state.ID.ValueString(), &diags)
+
+		return state.MetadataTypeActual.ValueString(), diags
+	}
+
+	passwordValue, descriptionValue, secretDiags := resolveSecretUpdateInputs(
+		ctx,
+		r.client,
+		config,
+		plan,
+		state,
+		rotateGenerated,
+	)
 	diags.Append(secretDiags...)
 	if diags.HasError() {
 		return "", diags
 	}
 
+	extra, err := passwordExtraFieldsFrom(plan, state)
+	if err != nil {
+		diags.AddError("Invalid custom_fields", err.Error())
+
+		return "", diags
+	}
+
 	metadataTypeActual, err := ensurePassboltPasswordMetadataType(
 		ctx,
 		r.client,
@@ -663,7 +1048,7 @@ func updateResourceFields(
 		desiredMetadataType(plan.MetadataType),
 		plan.Name.ValueString(),
 		plan.Username.ValueString(),
-		plan.URI.ValueString(),
+		extra.URIs,
 		descriptionValue,
 	)
 	if err != nil {
@@ -672,16 +1057,22 @@ func updateResourceFields(
 		return "", diags
 	}
 
+	if err := extra.checkMetadataType(metadataTypeActual); err != nil {
+		diags.AddError("Error updating resource", err.Error())
+
+		return "", diags
+	}
+
 	err = updatePassboltPasswordResource(
 		ctx,
 		r.client,
 		state.ID.ValueString(),
 		plan.Name.ValueString(),
 		plan.Username.ValueString(),
-		plan.URI.ValueString(),
-		passwordStringChanged(plan.URI, state.URI),
+		passwordURIsChanged(plan, state),
 		passwordValue,
 		descriptionValue,
+		extra,
 	)
 	if err != nil {
 		diags.AddError("Error updating resource", err.Error())
@@ -702,7 +1093,7 @@ func ensurePassboltPasswordMetadataType(
 	metadataType string,
 	name string,
 	username string,
-	uri string,
+	uris []string,
 	description string,
 ) (string, error) {
 	resourceData, resourceType, err := loadPasswordResourceType(ctx, client, resourceID)
@@ -729,7 +1120,7 @@ func ensurePassboltPasswordMetadataType(
 			resourceType,
 			name,
 			username,
-			uri,
+			uris,
 			description,
 		); err != nil {
 			return "", err
@@ -748,7 +1139,7 @@ func upgradePassboltPasswordToV5(
 	resourceType *api.ResourceType,
 	name string,
 	username string,
-	uri string,
+	uris []string,
 	description string,
 ) error {
 	if !client.Client.MetadataTypeSettings().AllowV4V5Upgrade {
@@ -774,7 +1165,7 @@ func upgradePassboltPasswordToV5(
 		v5ResourceType,
 		name,
 		username,
-		uri,
+		uris,
 		description,
 	)
 	if err != nil {
@@ -798,7 +1189,7 @@ func buildPasswordMetadataUpgradeRequest(
 	v5ResourceType *api.ResourceType,
 	name string,
 	username string,
-	uri string,
+	uris []string,
 	description string,
 ) (metadataUpgradeRequest, error) {
 	metadataKeyID, metadataKeyType, publicMetadataKey, err := client.Client.GetMetadataKey(ctx, false)
@@ -806,7 +1197,7 @@ func buildPasswordMetadataUpgradeRequest(
 		return metadataUpgradeRequest{}, fmt.Errorf("get metadata key: %w", err)
 	}
 
-	metadata := passwordUpgradeMetadataPayload(v5ResourceType, name, username, uri, description)
+	metadata := passwordUpgradeMetadataPayload(v5ResourceType, name, username, uris, description)
 
 	encodedMetadata, err := json.Marshal(metadata)
 	if err != nil {
@@ -832,7 +1223,7 @@ func passwordUpgradeMetadataPayload(
 	v5ResourceType *api.ResourceType,
 	name string,
 	username string,
-	uri string,
+	uris []string,
 	description string,
 ) passwordMetadataPayload {
 	metadata := passwordMetadataPayload{
@@ -840,7 +1231,7 @@ func passwordUpgradeMetadataPayload(
 		ResourceTypeID: v5ResourceType.ID,
 		Name:           name,
 		Username:       username,
-		URIs:           []string{uri},
+		URIs:           uris,
 	}
 
 	if v5ResourceType.Slug == "v5-password-string" {
@@ -859,11 +1250,14 @@ func passwordV5ResourceTypeForUpgrade(
 }
 
 func passwordV5ResourceTypeSlugForUpgrade(resourceType *api.ResourceType) string {
-	if resourceType.Slug == "password-string" {
+	switch resourceType.Slug {
+	case "password-string":
 		return "v5-password-string"
+	case passwordResourceTypeV4WithTOTP:
+		return passwordResourceTypeV5WithTOTP
+	default:
+		return "v5-default"
 	}
-
-	return "v5-default"
 }
 
 func findPassboltResourceTypeBySlug(
@@ -871,7 +1265,7 @@ func findPassboltResourceTypeBySlug(
 	client *tools.PassboltClient,
 	slug string,
 ) (*api.ResourceType, error) {
-	resourceTypes, err := client.Client.GetResourceTypes(ctx, nil)
+	resourceTypes, err := client.ResourceTypes(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("getting resource types: %w", err)
 	}
@@ -885,16 +1279,66 @@ func findPassboltResourceTypeBySlug(
 	return nil, fmt.Errorf("cannot find resource type: %s", slug)
 }
 
+// readPassboltResourceFields decrypts the metadata and secret of a resource into field maps.
+func readPassboltResourceFields(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	resourceData *api.Resource,
+) (map[string]any, map[string]any, error) {
+	resourceType, err := findPassboltResourceTypeByID(ctx, client, resourceData.ResourceTypeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secret, err := client.Client.GetSecret(ctx, resourceData.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting secret: %w", err)
+	}
+
+	_, metadataFields, secretFields, err := helper.GetResourceFieldMaps(
+		client.Client,
+		*resourceData,
+		*secret,
+		*resourceType,
+		true,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return metadataFields, secretFields, nil
+}
+
+// findPassboltResourceTypeByID looks a resource type up in the cached resource type list.
+func findPassboltResourceTypeByID(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	id string,
+) (*api.ResourceType, error) {
+	resourceTypes, err := client.ResourceTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource types: %w", err)
+	}
+
+	for i := range resourceTypes {
+		if resourceTypes[i].ID == id {
+			return &resourceTypes[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot find resource type: %s", id)
+}
+
 func updatePassboltPasswordResource(
 	ctx context.Context,
 	client *tools.PassboltClient,
 	resourceID string,
 	name string,
 	username string,
-	uri string,
 	updateURI bool,
 	password string,
 	description string,
+	extra passwordExtraFields,
 ) error {
 	resourceData, resourceType, err := loadPasswordResourceType(ctx, client, resourceID)
 	if err != nil {
@@ -909,14 +1353,20 @@ func updatePassboltPasswordResource(
 			resourceID,
 			name,
 			username,
-			uri,
+			extra.URIs[0],
 			updateURI,
 			password,
 			description,
 		)
 	}
 
-	metadataUpdates, secretUpdates := passwordUpdateMaps(name, username, uri, updateURI, password, description)
+	metadataUpdates, secretUpdates := passwordUpdateMaps(name, username, extra.URIs[0], updateURI, password, description)
+	if updateURI {
+		// go-passbolt turns uris back into uri for v4 resource types.
+		delete(metadataUpdates, "uri")
+		metadataUpdates["uris"] = extra.URIs
+	}
+	extra.apply(metadataUpdates, secretUpdates, resourceData.Metadata != "")
 
 	return helper.UpdateResourceGeneric(ctx, client.Client, resourceID, metadataUpdates, secretUpdates)
 }
@@ -1127,15 +1577,26 @@ func shareResourceIfNeeded(
 	resourceID string,
 	diags *diag.Diagnostics,
 ) {
-	if len(plan.ShareGroups) > 0 {
-		shareResourceWithGroups(ctx, client, plan.ShareGroups, resourceID, diags)
+	grants, err := desiredPasswordGroupGrants(plan)
+	if err != nil {
+		diags.AddError("Invalid group permission", err.Error())
 
 		return
 	}
 
-	if !plan.ShareGroup.IsUnknown() && !plan.ShareGroup.IsNull() && plan.ShareGroup.ValueString() != "" {
-		shareResourceWithGroups(ctx, client, []types.String{plan.ShareGroup}, resourceID, diags)
+	shareResourceWithGroups(ctx, client, grants, plan.ShareAuthoritative.ValueBool(), resourceID, diags)
+}
+
+func configuredPasswordForCreate(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	config passwordModel,
+) (string, error) {
+	if hasGeneratedPasswordConfig(config) {
+		return generatePasswordFromConfig(ctx, client, config.Generate)
 	}
+
+	return configuredPassword(config), nil
 }
 
 func configuredPassword(config passwordModel) string {
@@ -1164,12 +1625,24 @@ func resolveSecretUpdateInputs(
 	config passwordModel,
 	plan passwordModel,
 	state passwordModel,
+	rotateGenerated bool,
 ) (string, string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	passwordValue := passwordForUpdate(config, state)
 	descriptionValue := plan.Description.ValueString()
 
-	if !hasWriteOnlyPasswordConfig(config) || passwordWOVersionChanged(config, state) {
+	if hasGeneratedPasswordConfig(config) && rotateGenerated {
+		generated, err := generatePasswordFromConfig(ctx, client, config.Generate)
+		if err != nil {
+			diags.AddError("Cannot generate password", err.Error())
+
+			return "", "", diags
+		}
+
+		return generated, descriptionValue, diags
+	}
+
+	if !keepsCurrentSecret(config, state) {
 		return passwordValue, descriptionValue, diags
 	}
 
@@ -1221,10 +1694,59 @@ func buildManagedPasswordState(plan, config passwordModel, id types.String) pass
 	return state
 }
 
+func keepsCurrentSecret(config, state passwordModel) bool {
+	if hasGeneratedPasswordConfig(config) || migratesToWriteOnlyPassword(config, state) {
+		return true
+	}
+
+	return hasWriteOnlyPasswordConfig(config) && !passwordWOVersionChanged(config, state)
+}
+
+func hasGeneratedPasswordConfig(config passwordModel) bool {
+	return config.Generate != nil
+}
+
+// generatedPasswordRotationRequired reports whether a generated secret must be replaced. Switching an existing
+// secret to generate mode rotates it, except right after import where the current secret is adopted as is.
+func generatedPasswordRotationRequired(config, state passwordModel, importedSecretModeUnknown bool) bool {
+	if !hasGeneratedPasswordConfig(config) {
+		return false
+	}
+
+	if state.Generate == nil {
+		return !importedSecretModeUnknown
+	}
+
+	return !config.Generate.Keepers.Equal(state.Generate.Keepers)
+}
+
 func hasWriteOnlyPasswordConfig(config passwordModel) bool {
 	return !config.PasswordWO.IsNull() && !config.PasswordWO.IsUnknown()
 }
 
+// migratesToWriteOnlyPassword reports whether an existing password moves to password_wo while keeping its
+// current secret.
+func migratesToWriteOnlyPassword(config, state passwordModel) bool {
+	return config.PasswordWOMigrate.ValueBool() && hasWriteOnlyPasswordConfig(config) && !usesWriteOnlyPassword(state)
+}
+
+// passwordContentChanged reports whether plan changes anything stored in the resource's metadata or secret, apart
+// from the password itself.
+func passwordContentChanged(plan, state passwordModel) bool {
+	if (plan.TOTP == nil) != (state.TOTP == nil) || plan.TOTP != nil && *plan.TOTP != *state.TOTP {
+		return true
+	}
+
+	return !plan.Name.Equal(state.Name) ||
+		!plan.Username.Equal(state.Username) ||
+		!plan.Description.Equal(state.Description) ||
+		passwordURIsChanged(plan, state) ||
+		!plan.Icon.Equal(state.Icon) ||
+		!plan.Color.Equal(state.Color) ||
+		!maps.Equal(plan.CustomFields, state.CustomFields) ||
+		!plan.MetadataType.Equal(state.MetadataType)
+}
+
 func usesWriteOnlyPassword(state passwordModel) bool {
 	return !state.PasswordWOVersion.IsNull() && !state.PasswordWOVersion.IsUnknown()
 }
@@ -1242,6 +1764,10 @@ func passwordWOVersionChanged(config, state passwordModel) bool {
 }
 
 func (r *passwordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state passwordModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -1,9 +1,18 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"terraform-provider-passbolt/tools"
 	"testing"
 
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/passbolt/go-passbolt/api"
 )
 
@@ -149,6 +158,11 @@ func TestPasswordV5ResourceTypeSlugForUpgrade(t *testing.T) {
 	if got := passwordV5ResourceTypeSlugForUpgrade(passwordDescriptionType); got != "v5-default" {
 		t.Fatalf("expected password-and-description to map to v5-default, got %q", got)
 	}
+
+	totpType := &api.ResourceType{Slug: "password-description-totp"}
+	if got := passwordV5ResourceTypeSlugForUpgrade(totpType); got != "v5-default-with-totp" {
+		t.Fatalf("expected password-description-totp to map to v5-default-with-totp, got %q", got)
+	}
 }
 
 func TestPasswordUpgradeMetadataPayloadRoutesDescriptionByResourceType(t *testing.T) {
@@ -158,7 +172,7 @@ func TestPasswordUpgradeMetadataPayloadRoutesDescriptionByResourceType(t *testin
 		&api.ResourceType{ID: "v5-default-id", Slug: "v5-default"},
 		"db-admin",
 		"admin",
-		"https://db.example.com",
+		[]string{"https://db.example.com"},
 		"production database credentials",
 	)
 	defaultJSON, err := json.Marshal(defaultPayload)
@@ -177,7 +191,7 @@ func TestPasswordUpgradeMetadataPayloadRoutesDescriptionByResourceType(t *testin
 		&api.ResourceType{ID: "v5-password-string-id", Slug: "v5-password-string"},
 		"db-admin",
 		"admin",
-		"https://db.example.com",
+		[]string{"https://db.example.com"},
 		"production database credentials",
 	)
 	passwordStringJSON, err := json.Marshal(passwordStringPayload)
@@ -208,3 +222,258 @@ func assertMapString(t *testing.T, values map[string]any, key string, want strin
 		t.Fatalf("expected key %q to be %q, got %q", key, want, gotString)
 	}
 }
+
+func TestDesiredPasswordGroupGrants(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		plan passwordModel
+		want map[string]int
+	}{
+		"share groups default to update": {
+			plan: passwordModel{
+				ShareGroups: []types.String{types.StringValue("DevOps"), types.StringValue("Audit")},
+			},
+			want: map[string]int{"DevOps": 7, "Audit": 7},
+		},
+		"permission map overrides and adds groups": {
+			plan: passwordModel{
+				ShareGroups: []types.String{types.StringValue("DevOps"), types.StringValue("Audit")},
+				ShareGroupPerms: map[string]types.String{
+					"Audit":  types.StringValue("read"),
+					"Admins": types.StringValue("owner"),
+				},
+			},
+			want: map[string]int{"DevOps": 7, "Audit": 1, "Admins": 15},
+		},
+		"legacy share group": {
+			plan: passwordModel{ShareGroup: types.StringValue("DevOps")},
+			want: map[string]int{"DevOps": 7},
+		},
+		"nothing configured": {
+			plan: passwordModel{ShareGroup: types.StringNull()},
+			want: map[string]int{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := desiredPasswordGroupGrants(test.plan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !maps.Equal(got, test.want) {
+				t.Fatalf("expected grants %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDesiredPasswordGroupGrantsRejectsUnknownPermission(t *testing.T) {
+	t.Parallel()
+
+	_, err := desiredPasswordGroupGrants(passwordModel{
+		ShareGroupPerms: map[string]types.String{"DevOps": types.StringValue("delete")},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported permission level")
+	}
+}
+
+func TestBuildAuthoritativeShareStateReportsActualGrants(t *testing.T) {
+	t.Parallel()
+
+	existing := passwordModel{
+		ShareGroups: []types.String{types.StringValue("DevOps"), types.StringValue("Removed")},
+		ShareGroupPerms: map[string]types.String{
+			"Audit": types.StringValue("read"),
+		},
+	}
+
+	shareGroup, shareGroups, perms := buildAuthoritativeShareState(existing, map[string]int{
+		"DevOps":    1,
+		"Audit":     7,
+		"Unmanaged": 7,
+		"Owners":    15,
+	})
+
+	if !shareGroup.IsNull() {
+		t.Fatalf("expected share_group to stay null, got %v", shareGroup)
+	}
+
+	wantGroups := []types.String{types.StringValue("DevOps"), types.StringValue("Unmanaged")}
+	if !slices.Equal(shareGroups, wantGroups) {
+		t.Fatalf("expected share_groups %v, got %v", wantGroups, shareGroups)
+	}
+
+	wantPerms := map[string]types.String{
+		"Audit":  types.StringValue("update"),
+		"DevOps": types.StringValue("read"),
+		"Owners": types.StringValue("owner"),
+	}
+	if !maps.Equal(perms, wantPerms) {
+		t.Fatalf("expected share_group_permissions %v, got %v", wantPerms, perms)
+	}
+}
+
+func TestBuildAuthoritativeShareStateKeepsMatchingConfig(t *testing.T) {
+	t.Parallel()
+
+	existing := passwordModel{
+		ShareGroup: types.StringValue("DevOps"),
+	}
+
+	shareGroup, shareGroups, perms := buildAuthoritativeShareState(existing, map[string]int{"DevOps": 7})
+
+	if shareGroup.ValueString() != "DevOps" {
+		t.Fatalf("expected share_group DevOps, got %v", shareGroup)
+	}
+	if shareGroups != nil || perms != nil {
+		t.Fatalf("expected unset share_groups and permissions, got %v and %v", shareGroups, perms)
+	}
+}
+
+func TestKeepsCurrentSecretWhenMigratingToWriteOnly(t *testing.T) {
+	t.Parallel()
+
+	writeOnly := func(version int64, migrate bool) passwordModel {
+		return passwordModel{
+			PasswordWO:        types.StringValue("new-secret"),
+			PasswordWOVersion: types.Int64Value(version),
+			PasswordWOMigrate: types.BoolValue(migrate),
+		}
+	}
+	legacy := passwordModel{Password: types.StringValue("old-secret"), PasswordWOVersion: types.Int64Null()}
+
+	tests := map[string]struct {
+		config passwordModel
+		state  passwordModel
+		want   bool
+	}{
+		"switch without migrate rotates": {
+			config: writeOnly(1, false),
+			state:  legacy,
+		},
+		"switch with migrate keeps secret": {
+			config: writeOnly(1, true),
+			state:  legacy,
+			want:   true,
+		},
+		"version bump after migration rotates": {
+			config: writeOnly(2, true),
+			state:  passwordModel{PasswordWOVersion: types.Int64Value(1)},
+		},
+		"unchanged version keeps secret": {
+			config: writeOnly(1, false),
+			state:  passwordModel{PasswordWOVersion: types.Int64Value(1)},
+			want:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := keepsCurrentSecret(test.config, test.state); got != test.want {
+				t.Fatalf("expected keepsCurrentSecret %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateResourceFieldsMigrationSendsNoSecret(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.PGP().KeyGeneration().AddUserId("Terraform", "terraform@example.com").New().GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	privateKey, _ := key.Armor()
+
+	legacy := passwordModel{
+		ID:                 types.StringValue("8e3874ae-4b40-590b-968a-418f704b9d9a"),
+		Name:               types.StringValue("db-admin"),
+		Password:           types.StringValue("old-secret"),
+		PasswordWOVersion:  types.Int64Null(),
+		MetadataTypeActual: types.StringValue(metadataTypeV5),
+	}
+	migrated := legacy
+	migrated.Password = types.StringNull()
+	migrated.PasswordWO = types.StringValue("new-secret")
+	migrated.PasswordWOVersion = types.Int64Value(1)
+	migrated.PasswordWOMigrate = types.BoolValue(true)
+	renamed := migrated
+	renamed.Name = types.StringValue("db-root")
+	rotated := migrated
+	rotated.PasswordWOMigrate = types.BoolNull()
+
+	tests := map[string]struct {
+		config       passwordModel
+		wantRequests bool
+		wantError    bool
+	}{
+		"migration": {
+			config: migrated,
+		},
+		"migration with other changes": {
+			config:    renamed,
+			wantError: true,
+		},
+		"rotation": {
+			config:       rotated,
+			wantRequests: true,
+			wantError:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			apiClient, err := api.NewClient(server.Client(), "", server.URL, privateKey, "")
+			if err != nil {
+				t.Fatalf("unexpected client error: %v", err)
+			}
+			r := &passwordResource{client: &tools.PassboltClient{Client: apiClient, URL: server.URL}}
+
+			actual, diags := updateResourceFields(context.Background(), r, test.config, test.config, legacy, false)
+			if diags.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got diagnostics %v", test.wantError, diags)
+			}
+			if (requests.Load() > 0) != test.wantRequests {
+				t.Fatalf("expected requests %t, got %d", test.wantRequests, requests.Load())
+			}
+			if !test.wantError && actual != metadataTypeV5 {
+				t.Fatalf("expected metadata type %s, got %s", metadataTypeV5, actual)
+			}
+		})
+	}
+}
+
+func TestBuildManagedPasswordStateAfterMigration(t *testing.T) {
+	t.Parallel()
+
+	plan := passwordModel{Password: types.StringValue("old-secret"), PasswordWOVersion: types.Int64Value(1)}
+	config := passwordModel{
+		PasswordWO:        types.StringValue("new-secret"),
+		PasswordWOVersion: types.Int64Value(1),
+		PasswordWOMigrate: types.BoolValue(true),
+	}
+
+	state := buildManagedPasswordState(plan, config, types.StringValue("resource-id"))
+	if !state.Password.IsNull() || !state.PasswordWO.IsNull() {
+		t.Fatalf("expected no secret in state, got %v and %v", state.Password, state.PasswordWO)
+	}
+	if state.PasswordWOVersion.ValueInt64() != 1 {
+		t.Fatalf("expected password_wo_version to be seeded, got %s", state.PasswordWOVersion)
+	}
+}
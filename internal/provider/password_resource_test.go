@@ -72,6 +72,94 @@ func TestAccPasswordResource_writeOnly(t *testing.T) {
 	})
 }
 
+func TestAccPasswordResource_generate(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	suffix := testAccSuffix()
+	passwordName := testAccName("acc-test-generate", suffix)
+	updatedPasswordName := testAccName("acc-test-generate-updated", suffix)
+	var generated string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPasswordGenerateConfig(baseURL, privateKey, passphrase, passwordName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("passbolt_password.example", "password"),
+					testCheckGeneratedPassword(baseURL, privateKey, passphrase, &generated, false),
+				),
+			},
+			{
+				Config: testPasswordGenerateConfig(baseURL, privateKey, passphrase, updatedPasswordName, "1"),
+				Check:  testCheckGeneratedPassword(baseURL, privateKey, passphrase, &generated, false),
+			},
+			{
+				Config: testPasswordGenerateConfig(baseURL, privateKey, passphrase, updatedPasswordName, "2"),
+				Check:  testCheckGeneratedPassword(baseURL, privateKey, passphrase, &generated, true),
+			},
+		},
+	})
+}
+
+func TestAccPasswordResource_authoritativeShareGroups(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE", "PASSBOLT_MANAGER_ID")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	managerID := os.Getenv("PASSBOLT_MANAGER_ID")
+	suffix := testAccSuffix()
+	passwordName := testAccName("acc-test-authoritative", suffix)
+	groups := []string{
+		testAccName("tf-acc-auth-group-1", suffix),
+		testAccName("tf-acc-auth-group-2", suffix),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPasswordAuthoritativeShareConfig(
+					baseURL, privateKey, passphrase, passwordName, managerID, groups, true,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("passbolt_password.shared", "share_groups.#", "1"),
+					resource.TestCheckResourceAttr(
+						"passbolt_password.shared",
+						"share_group_permissions."+groups[1],
+						"read",
+					),
+					testCheckPasswordPermissionTypeFromState(
+						baseURL, privateKey, passphrase, "passbolt_password.shared", "passbolt_group.g0", "Group", 7,
+					),
+					testCheckPasswordPermissionTypeFromState(
+						baseURL, privateKey, passphrase, "passbolt_password.shared", "passbolt_group.g1", "Group", 1,
+					),
+				),
+			},
+			{
+				Config: testPasswordAuthoritativeShareConfig(
+					baseURL, privateKey, passphrase, passwordName, managerID, groups, false,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("passbolt_password.shared", "share_group_permissions.%"),
+					testCheckPasswordGroupPermissionRevoked(
+						baseURL, privateKey, passphrase, "passbolt_password.shared", "passbolt_group.g1",
+					),
+				),
+			},
+		},
+	})
+}
+
 func TestAccPasswordResource_metadataTypeUpgrade(t *testing.T) {
 	t.Parallel()
 
@@ -495,6 +583,98 @@ resource "passbolt_password" "shared" {
 `, baseURL, privateKey, passphrase, groupResources.String(), name, username, uri, password, groupNames)
 }
 
+func testPasswordAuthoritativeShareConfig(
+	baseURL,
+	privateKey,
+	passphrase,
+	name,
+	managerID string,
+	groups []string,
+	shareSecondGroup bool,
+) string {
+	secondGroupPermissions := ""
+	if shareSecondGroup {
+		secondGroupPermissions = `
+  share_group_permissions = {
+    (passbolt_group.g1.name) = "read"
+  }`
+	}
+
+	return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_group" "g0" {
+  name     = "%s"
+  managers = ["%s"]
+}
+
+resource "passbolt_group" "g1" {
+  name     = "%s"
+  managers = ["%s"]
+}
+
+resource "passbolt_password" "shared" {
+  name         = "%s"
+  username     = "authoritative-user"
+  uri          = "https://authoritative.example.com"
+  password     = "authoritative-secret"
+  share_groups = [passbolt_group.g0.name]
+%s
+  share_groups_authoritative = true
+}
+`, baseURL, privateKey, passphrase, groups[0], managerID, groups[1], managerID, name, secondGroupPermissions)
+}
+
+func testCheckPasswordGroupPermissionRevoked(
+	baseURL,
+	privateKey,
+	passphrase,
+	passwordResourceAddress,
+	groupResourceAddress string,
+) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		password, ok := state.RootModule().Resources[passwordResourceAddress]
+		if !ok {
+			return fmt.Errorf("%s not found in Terraform state", passwordResourceAddress)
+		}
+		group, ok := state.RootModule().Resources[groupResourceAddress]
+		if !ok {
+			return fmt.Errorf("%s not found in Terraform state", groupResourceAddress)
+		}
+
+		ctx := context.Background()
+		client, err := api.NewClient(nil, "", baseURL, privateKey, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to create Passbolt API client: %w", err)
+		}
+		if err := client.Login(ctx); err != nil {
+			return fmt.Errorf("failed to log in to Passbolt API: %w", err)
+		}
+		defer func() {
+			_ = client.Logout(ctx)
+		}()
+
+		permissions, err := client.GetResourcePermissions(ctx, password.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get resource permissions: %w", err)
+		}
+
+		for _, permission := range permissions {
+			if permission.ARO == "Group" && permission.AROForeignKey == group.Primary.ID {
+				return fmt.Errorf("expected group %s to have no access, got type %d", group.Primary.ID, permission.Type)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testPasswordWithShareConfig(
 	baseURL,
 	privateKey,
@@ -604,6 +784,75 @@ resource "passbolt_password" "example" {
 `, baseURL, privateKey, passphrase, name, username, uri, password, version, descriptionBlock)
 }
 
+func testPasswordGenerateConfig(baseURL, privateKey, passphrase, name, rotation string) string {
+	return fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_password" "example" {
+  name     = "%s"
+  username = "generated-user"
+  uri      = "https://generated.example.com"
+
+  generate {
+    length  = 24
+    special = false
+    keepers = {
+      rotation = "%s"
+    }
+  }
+}
+`, baseURL, privateKey, passphrase, name, rotation)
+}
+
+func testCheckGeneratedPassword(
+	baseURL,
+	privateKey,
+	passphrase string,
+	previous *string,
+	expectRotation bool,
+) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		resourceState, ok := state.RootModule().Resources["passbolt_password.example"]
+		if !ok {
+			return fmt.Errorf("passbolt_password.example not found in Terraform state")
+		}
+
+		ctx := context.Background()
+		client, err := api.NewClient(nil, "", baseURL, privateKey, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to create Passbolt API client: %w", err)
+		}
+		if err := client.Login(ctx); err != nil {
+			return fmt.Errorf("failed to log in to Passbolt API: %w", err)
+		}
+		defer func() {
+			_ = client.Logout(ctx)
+		}()
+
+		_, _, _, _, password, _, err := helper.GetResource(ctx, client, resourceState.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch Passbolt resource %s: %w", resourceState.Primary.ID, err)
+		}
+
+		if len(password) != 24 {
+			return fmt.Errorf("expected generated password of length 24, got %d", len(password))
+		}
+		if *previous != "" && (password != *previous) != expectRotation {
+			return fmt.Errorf("expected password rotation %t after keepers change", expectRotation)
+		}
+
+		*previous = password
+
+		return nil
+	}
+}
+
 func passwordWriteOnlyStateChecks(version int64) []statecheck.StateCheck {
 	return []statecheck.StateCheck{
 		statecheck.ExpectKnownValue(
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource types that store a TOTP next to the password.
+const (
+	passwordResourceTypeV4WithTOTP = "password-description-totp"
+	passwordResourceTypeV5WithTOTP = "v5-default-with-totp"
+)
+
+type passwordTOTPModel struct {
+	SecretKey types.String `tfsdk:"secret_key"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	Digits    types.Int64  `tfsdk:"digits"`
+	Period    types.Int64  `tfsdk:"period"`
+}
+
+func passwordTOTPSchemaBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "TOTP second factor stored in the same Passbolt resource as the password. Passwords with " +
+			"this block use the `v5-default-with-totp` or `password-description-totp` resource type. Adding or " +
+			"removing the block changes the resource type and replaces the password.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplaceIf(
+				func(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+					resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+				},
+				"Adding or removing TOTP changes the Passbolt resource type.",
+				"Adding or removing TOTP changes the Passbolt resource type.",
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"secret_key": schema.StringAttribute{
+				Required:  true,
+				Sensitive: true,
+				Description: "Base32 TOTP seed, as shown by the service when enrolling an authenticator. Stored in " +
+					"Terraform state like `password`.",
+			},
+			"algorithm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(tools.DefaultTOTPAlgorithm),
+				Description: "HMAC algorithm: `SHA1`, `SHA256`, or `SHA512`. Defaults to `SHA1`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("SHA1", "SHA256", "SHA512"),
+				},
+			},
+			"digits": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(tools.DefaultTOTPDigits),
+				Description: "Number of digits in a code, between 6 and 8. Defaults to `6`.",
+				Validators: []validator.Int64{
+					int64validator.Between(6, 8),
+				},
+			},
+			"period": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(tools.DefaultTOTPPeriod),
+				Description: "Seconds a code stays valid. Defaults to `30`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// passwordResourceTypeSlug returns the resource type for a new password of the given metadata type.
+func passwordResourceTypeSlug(metadataType string, withTOTP bool) string {
+	switch {
+	case metadataType == metadataTypeV5 && withTOTP:
+		return passwordResourceTypeV5WithTOTP
+	case metadataType == metadataTypeV5:
+		return "v5-default"
+	case withTOTP:
+		return passwordResourceTypeV4WithTOTP
+	default:
+		return "password-and-description"
+	}
+}
+
+func (m *passwordTOTPModel) toTOTP() tools.TOTP {
+	return tools.TOTP{
+		SecretKey: m.SecretKey.ValueString(),
+		Algorithm: m.Algorithm.ValueString(),
+		Digits:    m.Digits.ValueInt64(),
+		Period:    m.Period.ValueInt64(),
+	}
+}
+
+// secretField returns the totp object in the shape Passbolt secrets expect.
+func (m *passwordTOTPModel) secretField() map[string]any {
+	totp := m.toTOTP()
+
+	return map[string]any{
+		"secret_key": totp.SecretKey,
+		"algorithm":  totp.Algorithm,
+		"digits":     totp.Digits,
+		"period":     totp.Period,
+	}
+}
+
+func passwordTOTPModelFrom(totp *tools.TOTP) *passwordTOTPModel {
+	if totp == nil {
+		return nil
+	}
+
+	period := totp.Period
+	if period == 0 {
+		period = tools.DefaultTOTPPeriod
+	}
+
+	return &passwordTOTPModel{
+		SecretKey: types.StringValue(totp.SecretKey),
+		Algorithm: types.StringValue(totp.Algorithm),
+		Digits:    types.Int64Value(totp.Digits),
+		Period:    types.Int64Value(period),
+	}
+}
+
+// passwordTOTPFromSecretFields returns the TOTP stored in a decrypted secret, or nil when there is none.
+func passwordTOTPFromSecretFields(secretFields map[string]any) (*tools.TOTP, error) {
+	raw, ok := secretFields["totp"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("reading TOTP: %w", err)
+	}
+
+	var totp tools.TOTP
+	if err := json.Unmarshal(encoded, &totp); err != nil {
+		return nil, fmt.Errorf("reading TOTP: %w", err)
+	}
+
+	return &totp, nil
+}
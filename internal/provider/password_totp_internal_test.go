@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"terraform-provider-passbolt/tools"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPasswordResourceTypeSlug(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		metadataType string
+		withTOTP     bool
+		want         string
+	}{
+		"v4":           {metadataType: metadataTypeV4, want: "password-and-description"},
+		"v4 with totp": {metadataType: metadataTypeV4, withTOTP: true, want: "password-description-totp"},
+		"v5":           {metadataType: metadataTypeV5, want: "v5-default"},
+		"v5 with totp": {metadataType: metadataTypeV5, withTOTP: true, want: "v5-default-with-totp"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := passwordResourceTypeSlug(tt.metadataType, tt.withTOTP); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPasswordTOTPSecretField(t *testing.T) {
+	t.Parallel()
+
+	model := &passwordTOTPModel{
+		SecretKey: types.StringValue("JBSWY3DPEHPK3PXP"),
+		Algorithm: types.StringValue("SHA256"),
+		Digits:    types.Int64Value(8),
+		Period:    types.Int64Value(60),
+	}
+
+	field := model.secretField()
+	if field["secret_key"] != "JBSWY3DPEHPK3PXP" || field["algorithm"] != "SHA256" {
+		t.Fatalf("unexpected totp secret field: %#v", field)
+	}
+	if field["digits"] != int64(8) || field["period"] != int64(60) {
+		t.Fatalf("unexpected totp secret field: %#v", field)
+	}
+}
+
+func TestPasswordTOTPModelFromDefaultsPeriod(t *testing.T) {
+	t.Parallel()
+
+	if got := passwordTOTPModelFrom(nil); got != nil {
+		t.Fatalf("expected nil model for a resource without TOTP, got %#v", got)
+	}
+
+	got := passwordTOTPModelFrom(&tools.TOTP{SecretKey: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6})
+	if got.Period.ValueInt64() != tools.DefaultTOTPPeriod {
+		t.Fatalf("expected period to default to %d, got %d", tools.DefaultTOTPPeriod, got.Period.ValueInt64())
+	}
+	if got.SecretKey.ValueString() != "JBSWY3DPEHPK3PXP" || got.Digits.ValueInt64() != 6 {
+		t.Fatalf("unexpected totp model: %#v", got)
+	}
+}
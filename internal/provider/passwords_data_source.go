@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &passwordsDataSource{}
+	_ datasource.DataSourceWithConfigure        = &passwordsDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &passwordsDataSource{}
+)
+
+// NewPasswordsDataSource is a helper function to simplify the provider implementation.
+func NewPasswordsDataSource() datasource.DataSource {
+	return &passwordsDataSource{}
+}
+
+// passwordsDataSource is the data source implementation.
+type passwordsDataSource struct {
+	client *tools.PassboltClient
+}
+
+type passwordsDataSourceModel struct {
+	Name               types.String         `tfsdk:"name"`
+	NameRegex          types.String         `tfsdk:"name_regex"`
+	Username           types.String         `tfsdk:"username"`
+	URI                types.String         `tfsdk:"uri"`
+	FolderPath         types.String         `tfsdk:"folder_path"`
+	Recursive          types.Bool           `tfsdk:"recursive"`
+	SharedWithGroup    types.String         `tfsdk:"shared_with_group"`
+	MetadataTypeActual types.String         `tfsdk:"metadata_type_actual"`
+	IncludeSecrets     types.Bool           `tfsdk:"include_secrets"`
+	Passwords          []passwordsItemModel `tfsdk:"passwords"`
+}
+
+type passwordsItemModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Username           types.String `tfsdk:"username"`
+	URI                types.String `tfsdk:"uri"`
+	FolderParentID     types.String `tfsdk:"folder_parent_id"`
+	FolderPath         types.String `tfsdk:"folder_path"`
+	MetadataTypeActual types.String `tfsdk:"metadata_type_actual"`
+	Password           types.String `tfsdk:"password"`
+}
+
+// passboltPasswordEntry is a resource listed from Passbolt with its metadata decrypted.
+type passboltPasswordEntry struct {
+	ID                 string
+	Name               string
+	Description        string
+	Username           string
+	URI                string
+	FolderParentID     string
+	MetadataTypeActual string
+	Password           string
+}
+
+// passwordsFilter holds the client-side filters applied after resource metadata is decrypted.
+type passwordsFilter struct {
+	Name      string
+	NameRegex *regexp.Regexp
+	Username  string
+	URI       string
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *passwordsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *passboltClient, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *passwordsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_passwords"
+}
+
+func (d *passwordsDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("name"),
+			path.MatchRoot("name_regex"),
+		),
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *passwordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists passwords/secrets visible to the provider user, optionally filtered by name, username, " +
+			"URI, folder, group share, and metadata format. Supports Passbolt v4 and v5 encrypted metadata " +
+			"resources. Secrets are only decrypted when `include_secrets` is `true`; Terraform will then persist " +
+			"the decrypted values in state.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return passwords with exactly this name. Conflicts with `name_regex`.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return passwords whose name matches this regular expression (RE2 syntax).",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return passwords with exactly this username.",
+			},
+			"uri": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return passwords with exactly this URI.",
+			},
+			"folder_path": schema.StringAttribute{
+				Optional: true,
+				Description: "Only return passwords stored in this folder. Accepts a unique folder name, a folder UUID, " +
+					"or an absolute path such as `/application_A/prod`.",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional: true,
+				Description: "Also return passwords stored in subfolders of `folder_path`. Defaults to `false`. " +
+					"Ignored when `folder_path` is unset.",
+			},
+			"shared_with_group": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return passwords shared with the Passbolt group of this name.",
+			},
+			"metadata_type_actual": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return passwords stored with this metadata format: `v4` or `v5`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(metadataTypeV4, metadataTypeV5),
+				},
+			},
+			"include_secrets": schema.BoolAttribute{
+				Optional: true,
+				Description: "Decrypt and return the secret of every matching password. Defaults to `false`. " +
+					"Decrypted values are masked in CLI output, but Terraform will persist them in state.",
+			},
+			"passwords": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching passwords, sorted by folder path and name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "UUID of the password/secret.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the password/secret in Passbolt.",
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+							Description: "Description field for the secret. For resource types that keep the description " +
+								"inside the encrypted secret, this is only set when `include_secrets` is `true`.",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "Username/login for this secret (if set).",
+						},
+						"uri": schema.StringAttribute{
+							Computed:    true,
+							Description: "URI where the password is used, e.g., a service address.",
+						},
+						"folder_parent_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Parent folder's UUID, or empty if top-level.",
+						},
+						"folder_path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Absolute path of the parent folder, or empty if top-level.",
+						},
+						"metadata_type_actual": schema.StringAttribute{
+							Computed:    true,
+							Description: "Actual remote metadata format for this password: `v4` or `v5`.",
+						},
+						"password": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The decrypted secret value. Only set when `include_secrets` is `true`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *passwordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !ensureLogin(ctx, d.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data passwordsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := passwordsFilter{
+		Name:     data.Name.ValueString(),
+		Username: data.Username.ValueString(),
+		URI:      data.URI.ValueString(),
+	}
+	if data.NameRegex.ValueString() != "" {
+		nameRegex, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+
+			return
+		}
+		filter.NameRegex = nameRegex
+	}
+
+	folders, err := getCachedPassboltFolders(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot get folders", err.Error())
+
+		return
+	}
+
+	pathsByID, err := buildFolderPathIndex(folders)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build folder paths", err.Error())
+
+		return
+	}
+
+	opts := &api.GetResourcesOptions{
+		ContainSecret: data.IncludeSecrets.ValueBool(),
+	}
+
+	if data.FolderPath.ValueString() != "" {
+		folderID, err := resolveFolderReferenceValue(folders, data.FolderPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid folder reference", err.Error())
+
+			return
+		}
+
+		opts.FilterHasParent = []string{folderID}
+		if data.Recursive.ValueBool() {
+			opts.FilterHasParent = folderSubtreeIDs(folders, folderID)
+		}
+	}
+
+	if data.SharedWithGroup.ValueString() != "" {
+		groupID, err := getgroupIDByName(ctx, d.client, data.SharedWithGroup.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot resolve group", err.Error())
+
+			return
+		}
+		opts.FilterIsSharedWithGroup = groupID
+	}
+
+	resources, err := d.client.Client.GetResources(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read resources", err.Error())
+
+		return
+	}
+
+	entries, err := decryptPassboltPasswords(
+		ctx,
+		d.client,
+		resources,
+		data.IncludeSecrets.ValueBool(),
+		func(passboltResource api.Resource) bool {
+			metadataTypeActual := data.MetadataTypeActual.ValueString()
+
+			return metadataTypeActual == "" ||
+				metadataTypeActual == actualMetadataTypeFromEncryptedMetadata(passboltResource.Metadata)
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read resources", err.Error())
+
+		return
+	}
+
+	data.Passwords = []passwordsItemModel{}
+	for _, entry := range entries {
+		if !filter.matches(entry.Name, entry.Username, entry.URI) {
+			continue
+		}
+
+		item := passwordsItemModel{
+			ID:                 types.StringValue(entry.ID),
+			Name:               types.StringValue(entry.Name),
+			Description:        types.StringValue(entry.Description),
+			Username:           types.StringValue(entry.Username),
+			URI:                types.StringValue(entry.URI),
+			FolderParentID:     types.StringValue(entry.FolderParentID),
+			FolderPath:         types.StringValue(pathsByID[entry.FolderParentID]),
+			MetadataTypeActual: types.StringValue(entry.MetadataTypeActual),
+			Password:           types.StringNull(),
+		}
+		if data.IncludeSecrets.ValueBool() {
+			item.Password = types.StringValue(entry.Password)
+		}
+
+		data.Passwords = append(data.Passwords, item)
+	}
+
+	slices.SortFunc(data.Passwords, func(a, b passwordsItemModel) int {
+		return cmp.Or(
+			cmp.Compare(a.FolderPath.ValueString(), b.FolderPath.ValueString()),
+			cmp.Compare(a.Name.ValueString(), b.Name.ValueString()),
+			cmp.Compare(a.ID.ValueString(), b.ID.ValueString()),
+		)
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (f passwordsFilter) matches(name, username, uri string) bool {
+	if f.Name != "" && name != f.Name {
+		return false
+	}
+	if f.NameRegex != nil && !f.NameRegex.MatchString(name) {
+		return false
+	}
+	if f.Username != "" && username != f.Username {
+		return false
+	}
+	if f.URI != "" && uri != f.URI {
+		return false
+	}
+
+	return true
+}
+
+// decryptPassboltPasswords decrypts the metadata of every listed resource accepted by keep. Secrets are only
+// decrypted when decryptSecrets is set, which requires the resources to be listed with ContainSecret.
+func decryptPassboltPasswords(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	resources []api.Resource,
+	decryptSecrets bool,
+	keep func(api.Resource) bool,
+) ([]passboltPasswordEntry, error) {
+	resourceTypes, err := client.ResourceTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting resource types: %w", err)
+	}
+
+	resourceTypesByID := make(map[string]api.ResourceType, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		resourceTypesByID[resourceType.ID] = resourceType
+	}
+
+	entries := make([]passboltPasswordEntry, 0, len(resources))
+	for _, passboltResource := range resources {
+		if keep != nil && !keep(passboltResource) {
+			continue
+		}
+
+		resourceType, ok := resourceTypesByID[passboltResource.ResourceTypeID]
+		if !ok {
+			return nil, fmt.Errorf(
+				"resource %s has unknown resource type %q",
+				passboltResource.ID,
+				passboltResource.ResourceTypeID,
+			)
+		}
+
+		var secret api.Secret
+		if decryptSecrets && len(passboltResource.Secrets) > 0 {
+			secret = passboltResource.Secrets[0]
+		}
+
+		folderParentID, name, username, uri, password, description, err := helper.GetResourceFromDataWithOptions(
+			client.Client,
+			passboltResource,
+			secret,
+			resourceType,
+			decryptSecrets,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting resource %s: %w", passboltResource.ID, err)
+		}
+
+		entries = append(entries, passboltPasswordEntry{
+			ID:                 passboltResource.ID,
+			Name:               name,
+			Description:        description,
+			Username:           username,
+			URI:                uri,
+			FolderParentID:     folderParentID,
+			MetadataTypeActual: actualMetadataTypeFromEncryptedMetadata(passboltResource.Metadata),
+			Password:           password,
+		})
+	}
+
+	return entries, nil
+}
+
+// folderSubtreeIDs returns rootID followed by the IDs of all of its descendant folders.
+func folderSubtreeIDs(folders []api.Folder, rootID string) []string {
+	childrenByParent := make(map[string][]string, len(folders))
+	for _, folder := range folders {
+		childrenByParent[folder.FolderParentID] = append(childrenByParent[folder.FolderParentID], folder.ID)
+	}
+
+	ids := []string{rootID}
+	seen := map[string]bool{rootID: true}
+	for i := 0; i < len(ids); i++ {
+		for _, childID := range childrenByParent[ids[i]] {
+			if seen[childID] {
+				continue
+			}
+			seen[childID] = true
+			ids = append(ids, childID)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestPasswordsFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter passwordsFilter
+		want   bool
+	}{
+		"no filters": {
+			filter: passwordsFilter{},
+			want:   true,
+		},
+		"exact name": {
+			filter: passwordsFilter{Name: "db-admin"},
+			want:   true,
+		},
+		"other name": {
+			filter: passwordsFilter{Name: "db"},
+		},
+		"name regex": {
+			filter: passwordsFilter{NameRegex: regexp.MustCompile(`^db-`)},
+			want:   true,
+		},
+		"name regex mismatch": {
+			filter: passwordsFilter{NameRegex: regexp.MustCompile(`^web-`)},
+		},
+		"username and uri": {
+			filter: passwordsFilter{Username: "admin", URI: "https://db.example.com"},
+			want:   true,
+		},
+		"uri mismatch": {
+			filter: passwordsFilter{Username: "admin", URI: "https://web.example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.filter.matches("db-admin", "admin", "https://db.example.com")
+			if got != test.want {
+				t.Fatalf("expected match %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFolderSubtreeIDs(t *testing.T) {
+	t.Parallel()
+
+	folders := []api.Folder{
+		{ID: "root", Name: "root"},
+		{ID: "child", Name: "child", FolderParentID: "root"},
+		{ID: "grandchild", Name: "grandchild", FolderParentID: "child"},
+		{ID: "sibling", Name: "sibling"},
+	}
+
+	got := folderSubtreeIDs(folders, "root")
+	slices.Sort(got)
+
+	want := []string{"child", "grandchild", "root"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected subtree %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,95 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPasswordsDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	suffix := testAccSuffix()
+	folderName := testAccName("acc-passwords-folder", suffix)
+	subfolderName := testAccName("acc-passwords-subfolder", suffix)
+	passwordName := testAccName("acc-passwords", suffix)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_folder" "parent" {
+  name = "%s"
+}
+
+resource "passbolt_folder" "child" {
+  name          = "%s"
+  folder_parent = passbolt_folder.parent.id
+}
+
+resource "passbolt_password" "top" {
+  name          = "%s-top"
+  username      = "list-user"
+  uri           = "https://list.example.com"
+  password      = "list-secret-top"
+  folder_parent = passbolt_folder.parent.id
+}
+
+resource "passbolt_password" "nested" {
+  name          = "%s-nested"
+  username      = "list-user"
+  uri           = "https://list.example.com"
+  password      = "list-secret-nested"
+  folder_parent = passbolt_folder.child.id
+}
+
+data "passbolt_passwords" "direct" {
+  folder_path = "/%s"
+  depends_on  = [passbolt_password.top, passbolt_password.nested]
+}
+
+data "passbolt_passwords" "recursive" {
+  folder_path     = "/%s"
+  recursive       = true
+  name_regex      = "^%s-"
+  include_secrets = true
+  depends_on      = [passbolt_password.top, passbolt_password.nested]
+}
+`, baseURL, privateKey, passphrase, folderName, subfolderName,
+					passwordName, passwordName, folderName, folderName, passwordName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.passbolt_passwords.direct", "passwords.#", "1"),
+					resource.TestCheckResourceAttr("data.passbolt_passwords.direct", "passwords.0.name", passwordName+"-top"),
+					resource.TestCheckNoResourceAttr("data.passbolt_passwords.direct", "passwords.0.password"),
+					resource.TestCheckResourceAttr("data.passbolt_passwords.recursive", "passwords.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.passbolt_passwords.recursive",
+						"passwords.0.password",
+						"list-secret-top",
+					),
+					resource.TestCheckResourceAttr(
+						"data.passbolt_passwords.recursive",
+						"passwords.1.folder_path",
+						"/"+folderName+"/"+subfolderName,
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+var (
+	errNoPermissionOwner   = errors.New("at least one entry must have the owner permission")
+	errDuplicatePermission = errors.New("duplicate permission entry")
+)
+
+// permissionEntryModel is one grant of the permissions set managed by passbolt_folder_permissions and
+// passbolt_password_permissions.
+type permissionEntryModel struct {
+	GroupName  types.String `tfsdk:"group_name"`
+	Username   types.String `tfsdk:"username"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+// desiredPermission is a configured permission entry resolved to its group or user.
+type desiredPermission struct {
+	Target permissionTarget
+	Type   int
+}
+
+// permissionEntriesAttribute returns the permissions attribute of the authoritative permission resources.
+func permissionEntriesAttribute(object string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		Required: true,
+		Description: fmt.Sprintf("Every group and user with access to the %[1]s. At least one entry must have the "+
+			"`owner` permission, so the %[1]s is never left without an owner.", object),
+		Validators: []validator.Set{
+			setvalidator.SizeAtLeast(1),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"group_name": schema.StringAttribute{
+					Optional: true,
+					Description: "The name of the Passbolt group to grant access to. Exactly one of " +
+						"`group_name` or `username` must be set.",
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("username")),
+					},
+				},
+				"username": schema.StringAttribute{
+					Optional: true,
+					Description: "The exact username/email address of the Passbolt user to grant access to. " +
+						"Exactly one of `group_name` or `username` must be set.",
+				},
+				"permission": schema.StringAttribute{
+					Required:    true,
+					Description: "Level of access to grant. Must be one of `read`, `update`, or `owner`.",
+					Validators: []validator.String{
+						stringvalidator.OneOf("read", "update", "owner"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// validatePermissionEntriesConfig reports duplicate principals and a missing owner in the permissions attribute of
+// config once its entries are known.
+func validatePermissionEntriesConfig(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var permissions types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("permissions"), &permissions)...)
+	if diags.HasError() || permissions.IsNull() || permissions.IsUnknown() {
+		return diags
+	}
+	for _, element := range permissions.Elements() {
+		if element.IsUnknown() {
+			return diags
+		}
+	}
+
+	var entries []permissionEntryModel
+	diags.Append(permissions.ElementsAs(ctx, &entries, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := validatePermissionEntries(entries); err != nil {
+		diags.AddAttributeError(path.Root("permissions"), "Invalid permissions", err.Error())
+	}
+
+	return diags
+}
+
+// validatePermissionEntries rejects configurations that list a group or user twice or leave the object without an
+// owner. Entries with unknown values are skipped.
+func validatePermissionEntries(entries []permissionEntryModel) error {
+	seen := make(map[string]bool, len(entries))
+	hasOwner := false
+	allKnown := true
+	for _, entry := range entries {
+		if entry.Permission.IsUnknown() {
+			allKnown = false
+		}
+		if entry.Permission.ValueString() == "owner" {
+			hasOwner = true
+		}
+
+		key, known := permissionEntryKey(entry)
+		if !known {
+			allKnown = false
+
+			continue
+		}
+		if seen[key] {
+			return fmt.Errorf("%w: %s", errDuplicatePermission, strings.Replace(key, ":", " ", 1))
+		}
+		seen[key] = true
+	}
+
+	if allKnown && !hasOwner {
+		return errNoPermissionOwner
+	}
+
+	return nil
+}
+
+func permissionEntryKey(entry permissionEntryModel) (string, bool) {
+	if entry.GroupName.IsUnknown() || entry.Username.IsUnknown() {
+		return "", false
+	}
+	if !entry.GroupName.IsNull() {
+		return passwordPermissionImportKindGroup + ":" + entry.GroupName.ValueString(), true
+	}
+
+	return passwordPermissionImportKindUser + ":" + strings.ToLower(entry.Username.ValueString()), true
+}
+
+// resolveDesiredPermissions validates entries and resolves each of them to its group or active user.
+func resolveDesiredPermissions(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	entries []permissionEntryModel,
+) ([]desiredPermission, error) {
+	if err := validatePermissionEntries(entries); err != nil {
+		return nil, err
+	}
+
+	desired := make([]desiredPermission, 0, len(entries))
+	for _, entry := range entries {
+		target, err := resolvePermissionTarget(ctx, client, entry.GroupName, entry.Username, true)
+		if err != nil {
+			return nil, fmt.Errorf("resolving permission target: %w", err)
+		}
+
+		permissionType, err := passwordPermissionStringToInt(entry.Permission.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		desired = append(desired, desiredPermission{Target: target, Type: permissionType})
+	}
+
+	return desired, nil
+}
+
+// permissionChanges returns the share operations that turn current into desired: new grants, changed permission
+// levels, and revocations of every grant that is not desired.
+func permissionChanges(current []api.Permission, desired []desiredPermission) ([]helper.ShareOperation, error) {
+	currentTypes := make(map[string]int, len(current))
+	for _, permission := range current {
+		currentTypes[permission.ARO+":"+permission.AROForeignKey] = permission.Type
+	}
+
+	changes := make([]helper.ShareOperation, 0, len(desired))
+	wanted := make(map[string]bool, len(desired))
+	hasOwner := false
+	for _, permission := range desired {
+		key := permission.Target.ARO + ":" + permission.Target.ID
+		if wanted[key] {
+			return nil, fmt.Errorf("%w: %s %s", errDuplicatePermission, permission.Target.Kind, permission.Target.Name)
+		}
+		wanted[key] = true
+		if permission.Type == 15 {
+			hasOwner = true
+		}
+
+		if currentType, ok := currentTypes[key]; ok && currentType == permission.Type {
+			continue
+		}
+
+		changes = append(changes, helper.ShareOperation{
+			Type:  permission.Type,
+			ARO:   permission.Target.ARO,
+			AROID: permission.Target.ID,
+		})
+	}
+	if !hasOwner {
+		return nil, errNoPermissionOwner
+	}
+
+	for _, permission := range current {
+		if wanted[permission.ARO+":"+permission.AROForeignKey] {
+			continue
+		}
+
+		changes = append(changes, helper.ShareOperation{
+			Type:  -1,
+			ARO:   permission.ARO,
+			AROID: permission.AROForeignKey,
+		})
+	}
+
+	return changes, nil
+}
+
+// permissionEntriesFrom converts remote permissions to entries. Usernames keep the spelling of prior when they only
+// differ in case, and principals that cannot be resolved are listed by UUID so they still show up as drift.
+func permissionEntriesFrom(
+	permissions []api.Permission,
+	groups []api.Group,
+	users []api.User,
+	prior []permissionEntryModel,
+) []permissionEntryModel {
+	groupNames := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+
+	entries := make([]permissionEntryModel, 0, len(permissions))
+	for _, permission := range permissions {
+		entry := permissionEntryModel{
+			GroupName:  types.StringNull(),
+			Username:   types.StringNull(),
+			Permission: types.StringValue(permissionIntToString(permission.Type)),
+		}
+
+		if permission.ARO == passwordPermissionAROUser {
+			username := cmp.Or(usernames[permission.AROForeignKey], permission.AROForeignKey)
+			for _, priorEntry := range prior {
+				if strings.EqualFold(priorEntry.Username.ValueString(), username) {
+					username = priorEntry.Username.ValueString()
+
+					break
+				}
+			}
+			entry.Username = types.StringValue(username)
+		} else {
+			entry.GroupName = types.StringValue(cmp.Or(groupNames[permission.AROForeignKey], permission.AROForeignKey))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	slices.SortFunc(entries, func(a, b permissionEntryModel) int {
+		return cmp.Or(
+			cmp.Compare(a.GroupName.ValueString(), b.GroupName.ValueString()),
+			cmp.Compare(a.Username.ValueString(), b.Username.ValueString()),
+		)
+	})
+
+	return entries
+}
+
+// readPermissionEntries converts remote permissions to entries with the current group names and usernames.
+func readPermissionEntries(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	permissions []api.Permission,
+	prior []permissionEntryModel,
+) ([]permissionEntryModel, error) {
+	groups, err := client.Groups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	users, err := client.Users(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return permissionEntriesFrom(permissions, groups, users, prior), nil
+}
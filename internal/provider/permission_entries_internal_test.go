@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+func TestPermissionChanges(t *testing.T) {
+	t.Parallel()
+
+	current := []api.Permission{
+		{ARO: passwordPermissionAROGroup, AROForeignKey: "admins", Type: 15},
+		{ARO: passwordPermissionAROGroup, AROForeignKey: "developers", Type: 1},
+		{ARO: passwordPermissionAROUser, AROForeignKey: "contractor", Type: 7},
+	}
+	desired := []desiredPermission{
+		{Target: permissionTarget{ARO: passwordPermissionAROGroup, ID: "admins"}, Type: 15},
+		{Target: permissionTarget{ARO: passwordPermissionAROGroup, ID: "developers"}, Type: 7},
+		{Target: permissionTarget{ARO: passwordPermissionAROUser, ID: "auditor"}, Type: 1},
+	}
+
+	got, err := permissionChanges(current, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []helper.ShareOperation{
+		{Type: 7, ARO: passwordPermissionAROGroup, AROID: "developers"},
+		{Type: 1, ARO: passwordPermissionAROUser, AROID: "auditor"},
+		{Type: -1, ARO: passwordPermissionAROUser, AROID: "contractor"},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestPermissionChangesUnchanged(t *testing.T) {
+	t.Parallel()
+
+	got, err := permissionChanges(
+		[]api.Permission{{ARO: passwordPermissionAROGroup, AROForeignKey: "admins", Type: 15}},
+		[]desiredPermission{{Target: permissionTarget{ARO: passwordPermissionAROGroup, ID: "admins"}, Type: 15}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no changes, got %v", got)
+	}
+}
+
+func TestPermissionChangesLastOwner(t *testing.T) {
+	t.Parallel()
+
+	_, err := permissionChanges(
+		[]api.Permission{{ARO: passwordPermissionAROGroup, AROForeignKey: "admins", Type: 15}},
+		[]desiredPermission{{Target: permissionTarget{ARO: passwordPermissionAROGroup, ID: "admins"}, Type: 7}},
+	)
+	if !errors.Is(err, errNoPermissionOwner) {
+		t.Fatalf("expected errNoPermissionOwner, got %v", err)
+	}
+}
+
+func TestPermissionChangesDuplicate(t *testing.T) {
+	t.Parallel()
+
+	target := permissionTarget{ARO: passwordPermissionAROUser, ID: "contractor", Kind: "user", Name: "c@example.com"}
+	_, err := permissionChanges(nil, []desiredPermission{{Target: target, Type: 1}, {Target: target, Type: 15}})
+	if !errors.Is(err, errDuplicatePermission) {
+		t.Fatalf("expected errDuplicatePermission, got %v", err)
+	}
+}
+
+func TestValidatePermissionEntries(t *testing.T) {
+	t.Parallel()
+
+	group := func(name, permission string) permissionEntryModel {
+		return permissionEntryModel{
+			GroupName:  types.StringValue(name),
+			Username:   types.StringNull(),
+			Permission: types.StringValue(permission),
+		}
+	}
+	user := func(name, permission string) permissionEntryModel {
+		return permissionEntryModel{
+			GroupName:  types.StringNull(),
+			Username:   types.StringValue(name),
+			Permission: types.StringValue(permission),
+		}
+	}
+
+	tests := map[string]struct {
+		entries []permissionEntryModel
+		want    error
+	}{
+		"valid": {
+			entries: []permissionEntryModel{group("Admins", "owner"), user("c@example.com", "read")},
+		},
+		"no owner": {
+			entries: []permissionEntryModel{group("Admins", "update")},
+			want:    errNoPermissionOwner,
+		},
+		"unknown permission skips owner check": {
+			entries: []permissionEntryModel{
+				group("Admins", "update"),
+				{GroupName: types.StringValue("Ops"), Username: types.StringNull(), Permission: types.StringUnknown()},
+			},
+		},
+		"duplicate group": {
+			entries: []permissionEntryModel{group("Admins", "owner"), group("Admins", "read")},
+			want:    errDuplicatePermission,
+		},
+		"duplicate user ignores case": {
+			entries: []permissionEntryModel{
+				group("Admins", "owner"),
+				user("c@example.com", "read"),
+				user("C@Example.com", "update"),
+			},
+			want: errDuplicatePermission,
+		},
+		"group and user with the same name": {
+			entries: []permissionEntryModel{group("ops", "owner"), user("ops", "read")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validatePermissionEntries(test.entries)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("expected %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestPermissionEntriesFrom(t *testing.T) {
+	t.Parallel()
+
+	permissions := []api.Permission{
+		{ARO: passwordPermissionAROUser, AROForeignKey: "u1", Type: 1},
+		{ARO: passwordPermissionAROGroup, AROForeignKey: "g1", Type: 15},
+		{ARO: passwordPermissionAROGroup, AROForeignKey: "g-gone", Type: 7},
+	}
+	groups := []api.Group{{ID: "g1", Name: "Admins"}}
+	users := []api.User{{ID: "u1", Username: "contractor@example.com"}}
+	prior := []permissionEntryModel{{Username: types.StringValue("Contractor@Example.com")}}
+
+	got := permissionEntriesFrom(permissions, groups, users, prior)
+	want := []permissionEntryModel{
+		{
+			GroupName:  types.StringNull(),
+			Username:   types.StringValue("Contractor@Example.com"),
+			Permission: types.StringValue("read"),
+		},
+		{GroupName: types.StringValue("Admins"), Username: types.StringNull(), Permission: types.StringValue("owner")},
+		{GroupName: types.StringValue("g-gone"), Username: types.StringNull(), Permission: types.StringValue("update")},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
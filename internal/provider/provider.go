@@ -4,20 +4,42 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"terraform-provider-passbolt/tools"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+const (
+	// mfaTOTPRetries is how many times a rejected TOTP code is retried, which covers codes generated at the
+	// end of a time window.
+	mfaTOTPRetries = 2
+	// mfaTOTPRetryDelay is the wait between TOTP attempts.
+	mfaTOTPRetryDelay = 5 * time.Second
 )
 
 var (
-	_ provider.Provider = &passboltProvider{}
+	_ provider.Provider                       = &passboltProvider{}
+	_ provider.ProviderWithEphemeralResources = &passboltProvider{}
+	_ provider.ProviderWithConfigValidators   = &passboltProvider{}
+	_ provider.ProviderWithListResources      = &passboltProvider{}
 )
 
 type passboltProvider struct {
@@ -28,6 +50,25 @@ type passboltProviderModel struct {
 	URL  types.String `tfsdk:"base_url"`
 	KEY  types.String `tfsdk:"private_key"`
 	PASS types.String `tfsdk:"passphrase"`
+
+	KeyFile    types.String `tfsdk:"private_key_file"`
+	PassFile   types.String `tfsdk:"passphrase_file"`
+	AccountKit types.String `tfsdk:"account_kit"`
+
+	MFATOTPSecret  types.String `tfsdk:"mfa_totp_secret"`
+	MFATOTPCommand types.List   `tfsdk:"mfa_totp_command"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
 }
 
 // New returns a Terraform provider implementation for Passbolt.
@@ -51,25 +92,157 @@ func (p *passboltProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 			"or via environment variables.",
 		Attributes: map[string]schema.Attribute{
 			"base_url": schema.StringAttribute{
-				Required: true,
-				Description: "Base URL for the Passbolt instance. " +
+				Optional: true,
+				Description: "Base URL for the Passbolt instance. Defaults to the domain of `account_kit`. " +
 					"Can also be provided via `PASSBOLT_URL` environment variable.",
 			},
 			"private_key": schema.StringAttribute{
-				Required: true,
+				Optional: true,
 				Description: "ASCII-armored PGP Private key of Passbolt user. " +
-					"Can also be provided via `PASSBOLT_KEY` env var.",
+					"Can also be provided via `PASSBOLT_KEY` env var. " +
+					"Conflicts with `private_key_file` and `account_kit`.",
+			},
+			"private_key_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a file holding the ASCII-armored PGP private key of the Passbolt user. " +
+					"Can also be provided via `PASSBOLT_KEY_FILE` environment variable. " +
+					"Conflicts with `private_key` and `account_kit`.",
+			},
+			"account_kit": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "Account kit exported from the Passbolt browser extension. The provider takes the " +
+					"private key and, unless `base_url` is set, the server URL from it, and checks after login " +
+					"that the key belongs to the kit's user. Can also be provided via `PASSBOLT_ACCOUNT_KIT` " +
+					"environment variable. Conflicts with `private_key` and `private_key_file`.",
 			},
 			"passphrase": schema.StringAttribute{
-				Required:  true,
+				Optional:  true,
 				Sensitive: true,
 				Description: "Passphrase for the user's private key. " +
-					"Can also be provided via `PASSBOLT_PASS` environment variable.",
+					"Can also be provided via `PASSBOLT_PASS` environment variable. Conflicts with `passphrase_file`.",
+			},
+			"passphrase_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a file holding the passphrase for the user's private key. Trailing newlines " +
+					"are ignored. Can also be provided via `PASSBOLT_PASS_FILE` environment variable. " +
+					"Conflicts with `passphrase`.",
+			},
+			"mfa_totp_secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "Base32 TOTP seed used to answer the Passbolt MFA challenge when the user has TOTP " +
+					"enabled. Can also be provided via `PASSBOLT_TOTP_SECRET` environment variable. " +
+					"Conflicts with `mfa_totp_command`.",
+			},
+			"mfa_totp_command": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Command, as executable followed by arguments, that prints a current TOTP code to " +
+					"stdout. It is run without a shell every time Passbolt asks for MFA. Can also be provided via " +
+					"`PASSBOLT_TOTP_COMMAND` environment variable as a space-separated command line. " +
+					"Conflicts with `mfa_totp_secret`.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+				Description: "PEM encoded CA certificates to trust in addition to the system pool, for example an " +
+					"internal CA. Can also be provided via `PASSBOLT_CA_CERT_PEM` environment variable. " +
+					"Conflicts with `ca_cert_file`.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a PEM encoded CA bundle to trust in addition to the system pool. " +
+					"Can also be provided via `PASSBOLT_CA_CERT_FILE` environment variable. " +
+					"Conflicts with `ca_cert_pem`.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional: true,
+				Description: "PEM encoded client certificate presented for mutual TLS. Requires `client_key`. " +
+					"Can also be provided via `PASSBOLT_CLIENT_CERT` environment variable.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Description: "PEM encoded private key of `client_cert`. " +
+					"Can also be provided via `PASSBOLT_CLIENT_KEY` environment variable.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional: true,
+				Description: "URL of the HTTP proxy used to reach Passbolt. Defaults to the standard " +
+					"`HTTPS_PROXY`, `HTTP_PROXY`, and `NO_PROXY` environment variables. " +
+					"Can also be provided via `PASSBOLT_PROXY_URL` environment variable.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional: true,
+				Description: "Skip verification of the Passbolt server certificate. Only use this for testing. " +
+					"Can also be provided via `PASSBOLT_INSECURE_SKIP_VERIFY` environment variable.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional: true,
+				Description: "Maximum duration of a single API request, including retries, as a Go duration such as " +
+					"`30s` or `2m`. Defaults to no timeout. " +
+					"Can also be provided via `PASSBOLT_REQUEST_TIMEOUT` environment variable.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "Number of times a throttled (429) or temporarily unavailable (502, 503, 504) request is " +
+					"retried. Only idempotent requests are retried after 5xx responses. Set to `0` to disable retries. " +
+					"Defaults to `3`. Can also be provided via `PASSBOLT_MAX_RETRIES` environment variable.",
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Optional: true,
+				Description: "Initial wait before retrying, doubled on every attempt with jitter, as a Go duration. " +
+					"Defaults to `1s`. Can also be provided via `PASSBOLT_RETRY_WAIT_MIN` environment variable.",
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Optional: true,
+				Description: "Maximum wait between retries, also capping the server's `Retry-After`, as a Go duration. " +
+					"Defaults to `30s`. Can also be provided via `PASSBOLT_RETRY_WAIT_MAX` environment variable.",
 			},
 		},
 	}
 }
 
+// ensureLogin logs the provider in before an operation's first API call and reports failures on diags.
+func ensureLogin(ctx context.Context, client *tools.PassboltClient, diags *diag.Diagnostics) bool {
+	if err := client.EnsureLogin(ctx); err != nil {
+		diags.AddError("Login failed", err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+func (p *passboltProvider) ConfigValidators(_ context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("private_key"),
+			path.MatchRoot("private_key_file"),
+			path.MatchRoot("account_kit"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("passphrase"),
+			path.MatchRoot("passphrase_file"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("mfa_totp_secret"),
+			path.MatchRoot("mfa_totp_command"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("ca_cert_pem"),
+			path.MatchRoot("ca_cert_file"),
+		),
+		providervalidator.RequiredTogether(
+			path.MatchRoot("client_cert"),
+			path.MatchRoot("client_key"),
+		),
+	}
+}
+
 func (p *passboltProvider) Configure(
 	ctx context.Context,
 	req provider.ConfigureRequest,
@@ -82,78 +255,148 @@ func (p *passboltProvider) Configure(
 		return
 	}
 
-	url := resolveStringAttr(ctx, config.URL, "url", "PASSBOLT_URL", &resp.Diagnostics)
-	key := resolveStringAttr(ctx, config.KEY, "private_key", "PASSBOLT_KEY", &resp.Diagnostics)
-	pass := resolveStringAttr(ctx, config.PASS, "passphrase", "PASSBOLT_PASS", &resp.Diagnostics)
+	// Login is deferred to the first API call, so credentials that are unknown during validation or planning only
+	// fail the operations that actually need the server.
+	var creds providerCredentials
+	unknownCredentials := unknownCredentialAttributes(config)
+	if len(unknownCredentials) == 0 {
+		creds = resolveCredentials(config, &resp.Diagnostics)
+	}
+
+	var totpCode tools.TOTPCodeFunc
+	unknownMFA := unknownMFAAttributes(config)
+	if len(unknownMFA) == 0 {
+		totpCode = resolveTOTPCode(ctx, config, &resp.Diagnostics)
+	}
+	httpClient := resolveHTTPClient(config, &resp.Diagnostics)
+	retryOptions := resolveRetryOptions(config, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, err := api.NewClient(nil, "", url, key, pass)
+	retryTransport := tools.NewRetryTransport(httpClient.Transport, retryOptions)
+	httpClient.Transport = retryTransport
+
+	client, err := api.NewClient(httpClient, "", creds.URL, creds.PrivateKey, creds.Passphrase)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to connect to passbolt", "Client Error: "+err.Error())
 
 		return
 	}
 
-	passboltClient := tools.PassboltClient{
+	passboltClient := &tools.PassboltClient{
 		Client:     client,
-		URL:        url,
-		Password:   pass,
-		PrivateKey: key,
+		URL:        creds.URL,
+		Password:   creds.Passphrase,
+		PrivateKey: creds.PrivateKey,
 	}
 
-	if err := tools.Login(ctx, &passboltClient); err != nil {
-		resp.Diagnostics.AddError("Login failed", err.Error())
+	if unknown := append(unknownCredentials, unknownMFA...); len(unknown) > 0 {
+		tflog.Debug(ctx, "Provider credentials are unknown, deferring login", map[string]any{
+			"attributes": unknown,
+		})
 
-		return
+		passboltClient.ConfigError = fmt.Errorf(
+			"the provider attributes %s were unknown when the provider was configured",
+			strings.Join(unknown, ", "),
+		)
 	}
 
-	resp.DataSourceData = &passboltClient
-	resp.ResourceData = &passboltClient
+	if creds.AccountKit != nil {
+		passboltClient.ExpectedUserID = creds.AccountKit.UserID
+	}
+
+	if totpCode != nil {
+		tools.EnableTOTP(passboltClient, totpCode, mfaTOTPRetries, mfaTOTPRetryDelay)
+	}
+
+	tools.EnableRelogin(retryTransport, passboltClient)
+
+	resp.DataSourceData = passboltClient
+	resp.ResourceData = passboltClient
+	resp.EphemeralResourceData = passboltClient
+	resp.ListResourceData = passboltClient
 }
 
-func resolveStringAttr(
-	_ context.Context,
-	val types.String,
-	attrName string,
-	envVar string,
-	diags *diag.Diagnostics,
-) string {
-	if val.IsUnknown() {
-		diags.AddAttributeError(
-			path.Root(attrName),
-			"Unknown "+attrName,
-			fmt.Sprintf("The %s value is unknown and cannot be used yet.", attrName),
-		)
+// unknownMFAAttributes returns the MFA attributes whose values are not known yet. Like unknown credentials, they
+// only fail the operations that have to log in.
+func unknownMFAAttributes(config passboltProviderModel) []string {
+	var unknown []string
+	if config.MFATOTPSecret.IsUnknown() {
+		unknown = append(unknown, "mfa_totp_secret")
+	}
 
-		return ""
+	if config.MFATOTPCommand.IsUnknown() || slices.ContainsFunc(config.MFATOTPCommand.Elements(), attr.Value.IsUnknown) {
+		unknown = append(unknown, "mfa_totp_command")
 	}
 
-	if val.IsNull() {
-		val = types.StringValue(os.Getenv(envVar))
+	return unknown
+}
+
+// resolveTOTPCode returns the TOTP code source configured for MFA, or nil when MFA is not configured.
+// Configuration takes precedence over the PASSBOLT_TOTP_SECRET and PASSBOLT_TOTP_COMMAND environment variables.
+// Callers check unknownMFAAttributes first.
+func resolveTOTPCode(ctx context.Context, config passboltProviderModel, diags *diag.Diagnostics) tools.TOTPCodeFunc {
+	var command []string
+	if !config.MFATOTPCommand.IsNull() {
+		diags.Append(config.MFATOTPCommand.ElementsAs(ctx, &command, false)...)
+		if diags.HasError() {
+			return nil
+		}
 	}
 
-	result := val.ValueString()
+	secret := config.MFATOTPSecret.ValueString()
+	if config.MFATOTPSecret.IsNull() && config.MFATOTPCommand.IsNull() {
+		secret = os.Getenv("PASSBOLT_TOTP_SECRET")
+		command = strings.Fields(os.Getenv("PASSBOLT_TOTP_COMMAND"))
+	}
 
-	if result == "" {
-		diags.AddAttributeError(
-			path.Root(attrName),
-			"Missing "+attrName,
-			fmt.Sprintf("No %s value was provided in configuration or environment variable %s.", attrName, envVar),
+	switch {
+	case secret != "" && len(command) > 0:
+		diags.AddError(
+			"Conflicting MFA configuration",
+			"Only one of mfa_totp_secret (PASSBOLT_TOTP_SECRET) and mfa_totp_command (PASSBOLT_TOTP_COMMAND) can be set.",
 		)
+
+		return nil
+	case secret != "":
+		if _, err := helper.GenerateOTPCode(secret, time.Now()); err != nil {
+			diags.AddAttributeError(
+				path.Root("mfa_totp_secret"),
+				"Invalid mfa_totp_secret",
+				"The TOTP seed must be a base32 encoded secret: "+err.Error(),
+			)
+
+			return nil
+		}
+
+		return tools.TOTPSecretCode(secret)
+	case len(command) > 0:
+		if command[0] == "" {
+			diags.AddAttributeError(
+				path.Root("mfa_totp_command"),
+				"Invalid mfa_totp_command",
+				"The first element of mfa_totp_command must be the executable to run.",
+			)
+
+			return nil
+		}
+
+		return tools.TOTPCommandCode(command)
 	}
 
-	return result
+	return nil
 }
 
 func (p *passboltProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewFoldersDataSource,
 		NewPasswordDataSource,
+		NewPasswordsDataSource,
 		NewUserDataSource,
 		NewGroupDataSource,
+		NewResourceTypesDataSource,
 	}
 }
 
@@ -161,9 +404,28 @@ func (p *passboltProvider) Resources(_ context.Context) []func() resource.Resour
 	return []func() resource.Resource{
 		NewFolderResource,
 		NewPasswordResource,
+		NewGenericResource,
 		NewPasswordPermissionResource,
+		NewPasswordPermissionsResource,
 		NewFolderPermissionResource,
+		NewFolderPermissionsResource,
 		NewGroupResource,
 		NewUserResource,
 	}
 }
+
+func (p *passboltProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewFolderListResource,
+		NewPasswordListResource,
+		NewGroupListResource,
+		NewUserListResource,
+	}
+}
+
+func (p *passboltProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPasswordEphemeralResource,
+		NewTOTPCodeEphemeralResource,
+	}
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerCredentials are the login inputs after configuration, files, environment variables, and the account kit
+// have been combined.
+type providerCredentials struct {
+	URL        string
+	PrivateKey string
+	Passphrase string
+	// AccountKit is set when the private key came from an account kit.
+	AccountKit *tools.AccountKit
+}
+
+// resolveCredentials resolves the login inputs. Configuration takes precedence over environment variables, and an
+// explicit base URL takes precedence over the account kit domain.
+func resolveCredentials(config passboltProviderModel, diags *diag.Diagnostics) providerCredentials {
+	var creds providerCredentials
+
+	key := configuredString(config.KEY, "private_key", diags)
+	keyFile := configuredString(config.KeyFile, "private_key_file", diags)
+	kitData := configuredString(config.AccountKit, "account_kit", diags)
+	if key == "" && keyFile == "" && kitData == "" {
+		key = os.Getenv("PASSBOLT_KEY")
+		keyFile = os.Getenv("PASSBOLT_KEY_FILE")
+		kitData = os.Getenv("PASSBOLT_ACCOUNT_KIT")
+
+		requireSingleEnvSource(diags, "private_key", map[string]string{
+			"PASSBOLT_KEY":         key,
+			"PASSBOLT_KEY_FILE":    keyFile,
+			"PASSBOLT_ACCOUNT_KIT": kitData,
+		})
+	}
+
+	switch {
+	case key != "":
+		creds.PrivateKey = key
+	case keyFile != "":
+		creds.PrivateKey = readCredentialFile(keyFile, "private_key_file", diags)
+	case kitData != "":
+		kit, err := tools.ParseAccountKit(kitData)
+		if err != nil {
+			diags.AddAttributeError(path.Root("account_kit"), "Invalid account_kit", err.Error())
+
+			break
+		}
+
+		creds.AccountKit = kit
+		creds.PrivateKey = kit.UserPrivateArmoredKey
+	default:
+		diags.AddAttributeError(
+			path.Root("private_key"),
+			"Missing private_key",
+			"No private key was provided. Set private_key, private_key_file, or account_kit, or the "+
+				"PASSBOLT_KEY, PASSBOLT_KEY_FILE, or PASSBOLT_ACCOUNT_KIT environment variable.",
+		)
+	}
+
+	pass := configuredString(config.PASS, "passphrase", diags)
+	passFile := configuredString(config.PassFile, "passphrase_file", diags)
+	if pass == "" && passFile == "" {
+		pass = os.Getenv("PASSBOLT_PASS")
+		passFile = os.Getenv("PASSBOLT_PASS_FILE")
+
+		requireSingleEnvSource(diags, "passphrase", map[string]string{
+			"PASSBOLT_PASS":      pass,
+			"PASSBOLT_PASS_FILE": passFile,
+		})
+	}
+
+	switch {
+	case pass != "":
+		creds.Passphrase = pass
+	case passFile != "":
+		// Editors and secret mounts usually end files with a newline that is not part of the passphrase.
+		creds.Passphrase = strings.TrimRight(readCredentialFile(passFile, "passphrase_file", diags), "\r\n")
+	default:
+		diags.AddAttributeError(
+			path.Root("passphrase"),
+			"Missing passphrase",
+			"No passphrase was provided. Set passphrase or passphrase_file, or the PASSBOLT_PASS or "+
+				"PASSBOLT_PASS_FILE environment variable.",
+		)
+	}
+
+	creds.URL = configuredString(config.URL, "base_url", diags)
+	if creds.URL == "" {
+		creds.URL = os.Getenv("PASSBOLT_URL")
+	}
+	if creds.URL == "" && creds.AccountKit != nil {
+		creds.URL = creds.AccountKit.Domain
+	}
+	if creds.URL == "" && !diags.HasError() {
+		diags.AddAttributeError(
+			path.Root("base_url"),
+			"Missing base_url",
+			"No base_url value was provided in configuration, environment variable PASSBOLT_URL, or an account kit.",
+		)
+	}
+
+	return creds
+}
+
+// unknownCredentialAttributes returns the credential attributes whose values are not known yet, for example
+// because they come from resources that have not been created. Such configurations can still be validated and
+// planned, and only fail once the provider has to log in.
+func unknownCredentialAttributes(config passboltProviderModel) []string {
+	attrs := map[string]types.String{
+		"account_kit":      config.AccountKit,
+		"base_url":         config.URL,
+		"passphrase":       config.PASS,
+		"passphrase_file":  config.PassFile,
+		"private_key":      config.KEY,
+		"private_key_file": config.KeyFile,
+	}
+
+	var unknown []string
+	for name, val := range attrs {
+		if val.IsUnknown() {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+
+	return unknown
+}
+
+// configuredString returns the configured value of attrName, or "" when it is null.
+func configuredString(val types.String, attrName string, diags *diag.Diagnostics) string {
+	if val.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root(attrName),
+			"Unknown "+attrName,
+			fmt.Sprintf("The %s value is unknown and cannot be used yet.", attrName),
+		)
+
+		return ""
+	}
+
+	return val.ValueString()
+}
+
+// requireSingleEnvSource reports an error when more than one environment variable supplies attrName.
+func requireSingleEnvSource(diags *diag.Diagnostics, attrName string, values map[string]string) {
+	var set []string
+	for envVar, value := range values {
+		if value != "" {
+			set = append(set, envVar)
+		}
+	}
+
+	if len(set) > 1 {
+		slices.Sort(set)
+		diags.AddAttributeError(
+			path.Root(attrName),
+			"Conflicting "+attrName+" environment variables",
+			fmt.Sprintf("Only one of %s can be set.", strings.Join(set, ", ")),
+		)
+	}
+}
+
+func readCredentialFile(filePath, attrName string, diags *diag.Diagnostics) string {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attrName),
+			"Cannot read "+attrName,
+			fmt.Sprintf("Reading %s: %s", filePath, err),
+		)
+
+		return ""
+	}
+
+	return string(data)
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"terraform-provider-passbolt/tools"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resolveHTTPClient builds the HTTP client passed to the Passbolt API client from the transport attributes.
+func resolveHTTPClient(config passboltProviderModel, diags *diag.Diagnostics) *http.Client {
+	opts := tools.HTTPClientOptions{
+		CACertPEM:     resolveOptionalStringAttr(config.CACertPEM, "ca_cert_pem", "PASSBOLT_CA_CERT_PEM", diags),
+		CACertFile:    resolveOptionalStringAttr(config.CACertFile, "ca_cert_file", "PASSBOLT_CA_CERT_FILE", diags),
+		ClientCertPEM: resolveOptionalStringAttr(config.ClientCert, "client_cert", "PASSBOLT_CLIENT_CERT", diags),
+		ClientKeyPEM:  resolveOptionalStringAttr(config.ClientKey, "client_key", "PASSBOLT_CLIENT_KEY", diags),
+		ProxyURL:      resolveOptionalStringAttr(config.ProxyURL, "proxy_url", "PASSBOLT_PROXY_URL", diags),
+	}
+
+	opts.InsecureSkipVerify = resolveInsecureSkipVerify(config.InsecureSkipVerify, diags)
+	opts.Timeout = resolveDurationAttr(config.RequestTimeout, "request_timeout", "PASSBOLT_REQUEST_TIMEOUT", 0, diags)
+
+	if diags.HasError() {
+		return nil
+	}
+
+	if opts.CACertPEM != "" && opts.CACertFile != "" {
+		diags.AddError(
+			"Conflicting CA certificate configuration",
+			"Only one of ca_cert_pem (PASSBOLT_CA_CERT_PEM) and ca_cert_file (PASSBOLT_CA_CERT_FILE) can be set.",
+		)
+
+		return nil
+	}
+
+	if (opts.ClientCertPEM == "") != (opts.ClientKeyPEM == "") {
+		diags.AddError(
+			"Incomplete client certificate configuration",
+			"client_cert (PASSBOLT_CLIENT_CERT) and client_key (PASSBOLT_CLIENT_KEY) must be set together.",
+		)
+
+		return nil
+	}
+
+	client, err := tools.NewHTTPClient(opts)
+	if err != nil {
+		diags.AddError("Invalid HTTP transport configuration", err.Error())
+
+		return nil
+	}
+
+	return client
+}
+
+// resolveOptionalStringAttr returns the configured value, falling back to envVar when the attribute is null.
+func resolveOptionalStringAttr(val types.String, attrName, envVar string, diags *diag.Diagnostics) string {
+	if val.IsNull() {
+		return os.Getenv(envVar)
+	}
+
+	return configuredString(val, attrName, diags)
+}
+
+func resolveInsecureSkipVerify(val types.Bool, diags *diag.Diagnostics) bool {
+	if val.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown insecure_skip_verify",
+			"The insecure_skip_verify value is unknown and cannot be used yet.",
+		)
+
+		return false
+	}
+
+	if !val.IsNull() {
+		return val.ValueBool()
+	}
+
+	raw := os.Getenv("PASSBOLT_INSECURE_SKIP_VERIFY")
+	if raw == "" {
+		return false
+	}
+
+	insecure, err := strconv.ParseBool(raw)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Invalid PASSBOLT_INSECURE_SKIP_VERIFY",
+			fmt.Sprintf("PASSBOLT_INSECURE_SKIP_VERIFY must be a boolean, got %q.", raw),
+		)
+	}
+
+	return insecure
+}
+
+// resolveRetryOptions returns the retry settings, starting from the tools defaults.
+func resolveRetryOptions(config passboltProviderModel, diags *diag.Diagnostics) tools.RetryOptions {
+	opts := tools.DefaultRetryOptions()
+
+	opts.MaxRetries = resolveMaxRetries(config.MaxRetries, opts.MaxRetries, diags)
+	opts.WaitMin = resolveDurationAttr(
+		config.RetryWaitMin, "retry_wait_min", "PASSBOLT_RETRY_WAIT_MIN", opts.WaitMin, diags,
+	)
+	opts.WaitMax = resolveDurationAttr(
+		config.RetryWaitMax, "retry_wait_max", "PASSBOLT_RETRY_WAIT_MAX", opts.WaitMax, diags,
+	)
+
+	if !diags.HasError() && opts.WaitMin > opts.WaitMax {
+		diags.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid retry_wait_min",
+			fmt.Sprintf("retry_wait_min (%s) must not be greater than retry_wait_max (%s).", opts.WaitMin, opts.WaitMax),
+		)
+	}
+
+	return opts
+}
+
+func resolveMaxRetries(val types.Int64, fallback int, diags *diag.Diagnostics) int {
+	if val.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown max_retries",
+			"The max_retries value is unknown and cannot be used yet.",
+		)
+
+		return fallback
+	}
+
+	if !val.IsNull() {
+		return int(val.ValueInt64())
+	}
+
+	raw := os.Getenv("PASSBOLT_MAX_RETRIES")
+	if raw == "" {
+		return fallback
+	}
+
+	retries, err := strconv.Atoi(raw)
+	if err != nil || retries < 0 {
+		diags.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid PASSBOLT_MAX_RETRIES",
+			fmt.Sprintf("PASSBOLT_MAX_RETRIES must be a non-negative integer, got %q.", raw),
+		)
+
+		return fallback
+	}
+
+	return retries
+}
+
+// resolveDurationAttr parses a positive Go duration from the attribute or envVar, returning fallback when unset.
+func resolveDurationAttr(
+	val types.String,
+	attrName string,
+	envVar string,
+	fallback time.Duration,
+	diags *diag.Diagnostics,
+) time.Duration {
+	raw := resolveOptionalStringAttr(val, attrName, envVar, diags)
+	if raw == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration <= 0 {
+		diags.AddAttributeError(
+			path.Root(attrName),
+			"Invalid "+attrName,
+			fmt.Sprintf("%s must be a positive duration such as \"30s\", got %q.", attrName, raw),
+		)
+
+		return fallback
+	}
+
+	return duration
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// idIdentityModel is the identity of resources that are identified by their Passbolt UUID alone.
+type idIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func idIdentitySchema(description string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       description,
+			},
+		},
+	}
+}
+
+// setIDIdentity records id as the identity of a resource. identity is nil when Terraform does not support resource
+// identity.
+func setIDIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String) diag.Diagnostics {
+	if id.IsNull() || id.IsUnknown() {
+		return nil
+	}
+
+	return setIdentity(ctx, identity, idIdentityModel{ID: id})
+}
+
+// importByIDOrIdentity imports a resource from an import ID, resolved to a UUID by resolveID, or from an identity.
+func importByIDOrIdentity(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+	resolveID func(id string) (string, diag.Diagnostics),
+) {
+	if req.ID == "" {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+
+		return
+	}
+
+	id, diags := resolveID(req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+var errPermissionPrincipalNotFound = errors.New("permission principal not found")
+
+// permissionPrincipalIdentity is the part of a permission identity that names the group or user holding the
+// permission. It records UUIDs rather than names, so renaming a group or user does not change the identity.
+type permissionPrincipalIdentity struct {
+	PrincipalType types.String `tfsdk:"principal_type"`
+	PrincipalID   types.String `tfsdk:"principal_id"`
+}
+
+type passwordPermissionIdentityModel struct {
+	ResourceID types.String `tfsdk:"resource_id"`
+	permissionPrincipalIdentity
+}
+
+type folderPermissionIdentityModel struct {
+	FolderID types.String `tfsdk:"folder_id"`
+	permissionPrincipalIdentity
+}
+
+// permissionIdentitySchema returns the identity schema of a permission on the object stored in objectAttribute.
+func permissionIdentitySchema(objectAttribute string, objectDescription string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			objectAttribute: identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       objectDescription,
+			},
+			"principal_type": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Type of the principal holding the permission: `group` or `user`.",
+			},
+			"principal_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The UUID of the group or user holding the permission.",
+			},
+		},
+	}
+}
+
+func newPermissionPrincipalIdentity(target permissionTarget) permissionPrincipalIdentity {
+	return permissionPrincipalIdentity{
+		PrincipalType: types.StringValue(target.Kind),
+		PrincipalID:   types.StringValue(target.ID),
+	}
+}
+
+// permissionTargetByID resolves the principal recorded in a permission identity to its current group name or
+// username.
+func permissionTargetByID(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	principal permissionPrincipalIdentity,
+) (permissionTarget, error) {
+	principalID := principal.PrincipalID.ValueString()
+
+	switch principal.PrincipalType.ValueString() {
+	case passwordPermissionImportKindGroup:
+		groups, err := client.Groups(ctx)
+		if err != nil {
+			return permissionTarget{}, fmt.Errorf("failed to get groups: %w", err)
+		}
+
+		for _, group := range groups {
+			if group.ID == principalID {
+				return permissionTarget{
+					ARO:  passwordPermissionAROGroup,
+					ID:   group.ID,
+					Kind: passwordPermissionImportKindGroup,
+					Name: group.Name,
+				}, nil
+			}
+		}
+	case passwordPermissionImportKindUser:
+		users, err := client.Users(ctx)
+		if err != nil {
+			return permissionTarget{}, fmt.Errorf("failed to get users: %w", err)
+		}
+
+		for _, user := range users {
+			if user.ID == principalID && !user.Deleted {
+				return permissionTarget{
+					ARO:  passwordPermissionAROUser,
+					ID:   user.ID,
+					Kind: passwordPermissionImportKindUser,
+					Name: user.Username,
+				}, nil
+			}
+		}
+	default:
+		return permissionTarget{}, fmt.Errorf(
+			"principal_type must be %q or %q, got %q",
+			passwordPermissionImportKindGroup,
+			passwordPermissionImportKindUser,
+			principal.PrincipalType.ValueString(),
+		)
+	}
+
+	return permissionTarget{}, fmt.Errorf(
+		"%w: no %s with UUID %q",
+		errPermissionPrincipalNotFound,
+		principal.PrincipalType.ValueString(),
+		principalID,
+	)
+}
+
+// hasPrincipal reports whether the identity records a principal. It does not for state written before permissions
+// had an identity.
+func (p permissionPrincipalIdentity) hasPrincipal() bool {
+	return p.PrincipalID.ValueString() != "" && p.PrincipalType.ValueString() != ""
+}
+
+// matchesName reports whether name still refers to the target. Usernames are compared ignoring case, like
+// userByUsername does.
+func (t permissionTarget) matchesName(name string) bool {
+	if t.Kind == passwordPermissionImportKindUser {
+		return strings.EqualFold(t.Name, name)
+	}
+
+	return t.Name == name
+}
+
+// currentPermissionTarget resolves the group or user of an existing permission. The principal UUID recorded in the
+// identity takes precedence over the name in state, so renaming the group or user does not orphan the permission.
+func currentPermissionTarget(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	principal permissionPrincipalIdentity,
+	resolveByName func() (permissionTarget, error),
+) (permissionTarget, error) {
+	if !principal.hasPrincipal() {
+		return resolveByName()
+	}
+
+	return permissionTargetByID(ctx, client, principal)
+}
+
+// getIdentity reads identity into target. It leaves target unchanged when there is no identity yet, for example in
+// state written by an older provider version.
+func getIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, target any) diag.Diagnostics {
+	if identity == nil || identity.Raw.IsNull() {
+		return nil
+	}
+
+	return identity.Get(ctx, target)
+}
+
+// setIdentity records value as the identity of a resource. identity is nil when Terraform does not support resource
+// identity.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, value any) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, value)
+}
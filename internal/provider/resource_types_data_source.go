@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &resourceTypesDataSource{}
+	_ datasource.DataSourceWithConfigure = &resourceTypesDataSource{}
+)
+
+// NewResourceTypesDataSource returns a data source that lists the resource types supported by the server.
+func NewResourceTypesDataSource() datasource.DataSource {
+	return &resourceTypesDataSource{}
+}
+
+type resourceTypesDataSource struct {
+	client *tools.PassboltClient
+}
+
+type resourceTypesDataSourceModel struct {
+	Slugs         []types.String       `tfsdk:"slugs"`
+	ResourceTypes []resourceTypesModel `tfsdk:"resource_types"`
+}
+
+type resourceTypesModel struct {
+	ID          types.String `tfsdk:"id"`
+	Slug        types.String `tfsdk:"slug"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Version     types.String `tfsdk:"version"`
+	Deprecated  types.Bool   `tfsdk:"deprecated"`
+	Definition  types.String `tfsdk:"definition"`
+}
+
+// passboltResourceTypeAPI is a resource type as returned by the API, including the fields go-passbolt drops.
+type passboltResourceTypeAPI struct {
+	ID          string          `json:"id"`
+	Slug        string          `json:"slug"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Definition  json.RawMessage `json:"definition"`
+	Deleted     any             `json:"deleted"`
+}
+
+type getResourceTypesOptions struct {
+	FilterIsDeleted bool `url:"filter[is-deleted],omitempty"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *resourceTypesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *passboltClient, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *resourceTypesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_resource_types"
+}
+
+// Schema defines the schema for the data source.
+func (d *resourceTypesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the resource types supported by the Passbolt server, including deprecated ones. " +
+			"Useful in preconditions, for example to check that `v5-default` is available before setting " +
+			"`metadata_type = \"v5\"`.",
+		Attributes: map[string]schema.Attribute{
+			"slugs": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Slugs of the resource types that are not deprecated, sorted alphabetically.",
+			},
+			"resource_types": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Resource types supported by the server, sorted by slug.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "UUID of the resource type.",
+						},
+						"slug": schema.StringAttribute{
+							Computed:    true,
+							Description: "Slug of the resource type, for example `v5-default`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Display name of the resource type.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the resource type.",
+						},
+						"version": schema.StringAttribute{
+							Computed:    true,
+							Description: "`v5` for resource types with encrypted metadata, `v4` otherwise.",
+						},
+						"deprecated": schema.BoolAttribute{
+							Computed:    true,
+							Description: "True if a Passbolt administrator has deprecated the resource type.",
+						},
+						"definition": schema.StringAttribute{
+							Computed: true,
+							Description: "JSON schema definition of the resource type, with `resource` and `secret` " +
+								"members. Use `jsondecode` to inspect it. Some servers return `[]` for built-in types.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *resourceTypesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !ensureLogin(ctx, d.client, &resp.Diagnostics) {
+		return
+	}
+
+	resourceTypes, err := getPassboltResourceTypes(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read resource types", err.Error())
+
+		return
+	}
+
+	state := resourceTypesDataSourceModel{
+		Slugs:         []types.String{},
+		ResourceTypes: make([]resourceTypesModel, 0, len(resourceTypes)),
+	}
+	for _, resourceType := range resourceTypes {
+		model := resourceTypeModelFrom(resourceType)
+		if !model.Deprecated.ValueBool() {
+			state.Slugs = append(state.Slugs, model.Slug)
+		}
+
+		state.ResourceTypes = append(state.ResourceTypes, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// getPassboltResourceTypes lists active and deprecated resource types, sorted by slug. Servers that do not know
+// the deleted filter return the active types twice, so the lists are merged by ID.
+func getPassboltResourceTypes(ctx context.Context, client *tools.PassboltClient) ([]passboltResourceTypeAPI, error) {
+	active, err := listPassboltResourceTypes(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecated, err := listPassboltResourceTypes(ctx, client, &getResourceTypesOptions{FilterIsDeleted: true})
+	if err != nil {
+		if !isUnsupportedFilterError(err) {
+			return nil, fmt.Errorf("listing deprecated resource types: %w", err)
+		}
+
+		// Older servers cannot deprecate resource types.
+		deprecated = nil
+	}
+
+	return mergePassboltResourceTypes(active, deprecated), nil
+}
+
+// isUnsupportedFilterError reports whether the server rejected the request because it does not know the filter.
+func isUnsupportedFilterError(err error) bool {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound
+}
+
+func listPassboltResourceTypes(
+	ctx context.Context,
+	client *tools.PassboltClient,
+	opts *getResourceTypesOptions,
+) ([]passboltResourceTypeAPI, error) {
+	msg, err := client.Client.DoCustomRequestV5(ctx, "GET", "/resource-types.json", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceTypes []passboltResourceTypeAPI
+	if err := json.Unmarshal(msg.Body, &resourceTypes); err != nil {
+		return nil, err
+	}
+
+	return resourceTypes, nil
+}
+
+func mergePassboltResourceTypes(lists ...[]passboltResourceTypeAPI) []passboltResourceTypeAPI {
+	seen := map[string]bool{}
+	var merged []passboltResourceTypeAPI
+	for _, list := range lists {
+		for _, resourceType := range list {
+			if seen[resourceType.ID] {
+				continue
+			}
+
+			seen[resourceType.ID] = true
+			merged = append(merged, resourceType)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Slug < merged[j].Slug
+	})
+
+	return merged
+}
+
+func resourceTypeModelFrom(resourceType passboltResourceTypeAPI) resourceTypesModel {
+	version := metadataTypeV4
+	if (&api.ResourceType{Slug: resourceType.Slug}).IsV5() {
+		version = metadataTypeV5
+	}
+
+	return resourceTypesModel{
+		ID:          types.StringValue(resourceType.ID),
+		Slug:        types.StringValue(resourceType.Slug),
+		Name:        types.StringValue(resourceType.Name),
+		Description: types.StringValue(resourceType.Description),
+		Version:     types.StringValue(version),
+		Deprecated:  types.BoolValue(resourceType.Deleted != nil && resourceType.Deleted != false),
+		Definition:  types.StringValue(resourceTypeDefinitionJSON(resourceType.Definition)),
+	}
+}
+
+// resourceTypeDefinitionJSON returns the definition as a JSON document. Some Passbolt versions return it as an
+// escaped JSON string.
+func resourceTypeDefinitionJSON(definition json.RawMessage) string {
+	var encoded string
+	if err := json.Unmarshal(definition, &encoded); err == nil && json.Valid([]byte(encoded)) {
+		return encoded
+	}
+
+	return string(definition)
+}
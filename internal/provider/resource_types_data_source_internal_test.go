@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestMergePassboltResourceTypesDeduplicatesAndSorts(t *testing.T) {
+	t.Parallel()
+
+	active := []passboltResourceTypeAPI{
+		{ID: "2", Slug: "v5-default"},
+		{ID: "1", Slug: "password-and-description"},
+	}
+	// Servers without the deleted filter return the active list again.
+	deprecated := []passboltResourceTypeAPI{
+		{ID: "2", Slug: "v5-default"},
+		{ID: "3", Slug: "password-string", Deleted: "2025-01-01T00:00:00+00:00"},
+	}
+
+	merged := mergePassboltResourceTypes(active, deprecated)
+
+	want := []string{"password-and-description", "password-string", "v5-default"}
+	if len(merged) != len(want) {
+		t.Fatalf("expected %d resource types, got %#v", len(want), merged)
+	}
+	for i, slug := range want {
+		if merged[i].Slug != slug {
+			t.Fatalf("expected %s at index %d, got %s", slug, i, merged[i].Slug)
+		}
+	}
+}
+
+func TestIsUnsupportedFilterError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"bad request": {
+			err:  fmt.Errorf("listing: %w", &api.APIError{StatusCode: 400, Message: "Invalid filter."}),
+			want: true,
+		},
+		"not found": {
+			err:  &api.APIError{StatusCode: 404, Message: "Not found."},
+			want: true,
+		},
+		"forbidden": {
+			err: &api.APIError{StatusCode: 403, Message: "Forbidden."},
+		},
+		"server error": {
+			err: &api.APIError{StatusCode: 500, Message: "Internal error."},
+		},
+		"transport error": {
+			err: errors.New("doing Request: connection refused"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isUnsupportedFilterError(tt.err); got != tt.want {
+				t.Fatalf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResourceTypeModelFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resourceType   passboltResourceTypeAPI
+		wantVersion    string
+		wantDeprecated bool
+		wantDefinition string
+	}{
+		"v5 type": {
+			resourceType:   passboltResourceTypeAPI{Slug: "v5-default", Definition: json.RawMessage(`[]`)},
+			wantVersion:    metadataTypeV5,
+			wantDefinition: `[]`,
+		},
+		"deprecated v4 type": {
+			resourceType: passboltResourceTypeAPI{
+				Slug:       "password-string",
+				Definition: json.RawMessage(`{"resource":{},"secret":{"type":"string"}}`),
+				Deleted:    "2025-01-01T00:00:00+00:00",
+			},
+			wantVersion:    metadataTypeV4,
+			wantDeprecated: true,
+			wantDefinition: `{"resource":{},"secret":{"type":"string"}}`,
+		},
+		"escaped definition": {
+			resourceType: passboltResourceTypeAPI{
+				Slug:       "password-and-description",
+				Definition: json.RawMessage(`"{\"resource\":{}}"`),
+				Deleted:    false,
+			},
+			wantVersion:    metadataTypeV4,
+			wantDefinition: `{"resource":{}}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			model := resourceTypeModelFrom(tt.resourceType)
+			if model.Version.ValueString() != tt.wantVersion {
+				t.Fatalf("expected version %s, got %s", tt.wantVersion, model.Version)
+			}
+			if model.Deprecated.ValueBool() != tt.wantDeprecated {
+				t.Fatalf("expected deprecated %t, got %s", tt.wantDeprecated, model.Deprecated)
+			}
+			if model.Definition.ValueString() != tt.wantDefinition {
+				t.Fatalf("expected definition %s, got %s", tt.wantDefinition, model.Definition)
+			}
+		})
+	}
+}
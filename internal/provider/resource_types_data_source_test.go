@@ -0,0 +1,47 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceTypesDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+data "passbolt_resource_types" "all" {}
+
+output "supports_v5_default" {
+  value = contains(data.passbolt_resource_types.all.slugs, "v5-default")
+}
+`, baseURL, privateKey, passphrase),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.passbolt_resource_types.all", "resource_types.0.id"),
+					resource.TestCheckResourceAttrSet("data.passbolt_resource_types.all", "resource_types.0.version"),
+					resource.TestCheckOutput("supports_v5_default", "true"),
+				),
+			},
+		},
+	})
+}
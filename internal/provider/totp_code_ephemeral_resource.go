@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-passbolt/tools"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &totpCodeEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &totpCodeEphemeralResource{}
+)
+
+// NewTOTPCodeEphemeralResource returns a Terraform ephemeral resource that computes TOTP codes from Passbolt.
+func NewTOTPCodeEphemeralResource() ephemeral.EphemeralResource {
+	return &totpCodeEphemeralResource{}
+}
+
+// totpCodeEphemeralResource is the ephemeral resource implementation.
+type totpCodeEphemeralResource struct {
+	client *tools.PassboltClient
+}
+
+type totpCodeEphemeralResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Code      types.String `tfsdk:"code"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *totpCodeEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*tools.PassboltClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *PassboltClient, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *totpCodeEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_totp_code"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *totpCodeEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Computes the current TOTP code of a Passbolt resource that stores a TOTP secret, such as a " +
+			"`passbolt_password` with a `totp` block. Neither the seed nor the code is stored in plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:    true,
+				Description: "The Passbolt resource UUID.",
+			},
+			"code": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The TOTP code valid when the ephemeral resource was opened.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 timestamp at which `code` stops being valid.",
+			},
+		},
+	}
+}
+
+// Open decrypts the TOTP secret of the requested resource and computes the current code.
+func (e *totpCodeEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	if !ensureLogin(ctx, e.client, &resp.Diagnostics) {
+		return
+	}
+
+	var data totpCodeEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resourceData, err := e.client.Client.GetResource(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read resource "+data.ID.ValueString(), err.Error())
+
+		return
+	}
+
+	_, secretFields, err := readPassboltResourceFields(ctx, e.client, resourceData)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to decrypt resource "+data.ID.ValueString(), err.Error())
+
+		return
+	}
+
+	totp, err := passwordTOTPFromSecretFields(secretFields)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read TOTP of resource "+data.ID.ValueString(), err.Error())
+
+		return
+	}
+	if totp == nil {
+		resp.Diagnostics.AddError(
+			"Resource has no TOTP",
+			fmt.Sprintf("Passbolt resource %s does not store a TOTP secret.", data.ID.ValueString()),
+		)
+
+		return
+	}
+
+	now := time.Now()
+	code, err := totp.Code(now)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to compute TOTP code", err.Error())
+
+		return
+	}
+
+	data.Code = types.StringValue(code)
+	data.ExpiresAt = types.StringValue(totp.Expiry(now).UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,84 @@
+package provider_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTOTPCodeEphemeralResource_basic(t *testing.T) {
+	t.Parallel()
+
+	requireAcceptanceEnv(t, "PASSBOLT_BASE_URL", "PASSBOLT_PRIVATE_KEY", "PASSBOLT_PASSPHRASE")
+
+	baseURL := os.Getenv("PASSBOLT_BASE_URL")
+	privateKey := os.Getenv("PASSBOLT_PRIVATE_KEY")
+	passphrase := os.Getenv("PASSBOLT_PASSPHRASE")
+	resourceName := testAccName("acc-totp-test", testAccSuffix())
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"passbolt": testAccProviderFactories["passbolt"],
+			"echo":     echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "passbolt" {
+  base_url    = "%s"
+  private_key = <<EOF
+%s
+EOF
+  passphrase  = "%s"
+}
+
+resource "passbolt_password" "example" {
+  name     = "%s"
+  username = "break-glass"
+  uri      = "https://totp.example.com"
+  password = "break-glass-secret"
+
+  totp {
+    secret_key = "JBSWY3DPEHPK3PXP"
+    digits     = 8
+  }
+}
+
+ephemeral "passbolt_totp_code" "by_id" {
+  id = passbolt_password.example.id
+}
+
+provider "echo" {
+  data = ephemeral.passbolt_totp_code.by_id
+}
+
+resource "echo" "test" {}
+`, baseURL, privateKey, passphrase, resourceName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"passbolt_password.example",
+						tfjsonpath.New("totp").AtMapKey("algorithm"),
+						knownvalue.StringExact("SHA1"),
+					),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("code"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^[0-9]{8}$`)),
+					),
+				},
+			},
+		},
+	})
+}
@@ -105,15 +105,17 @@ func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 }
 
 func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !ensureLogin(ctx, d.client, &resp.Diagnostics) {
+		return
+	}
+
 	var config userDataSourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	users, err := d.client.Client.GetUsers(ctx, &api.GetUsersOptions{
-		FilterSearch: config.Username.ValueString(),
-	})
+	users, err := d.client.Users(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("User not found",
 			fmt.Sprintf("Could not find user with username: %s",
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"terraform-provider-passbolt/tools"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &userListResource{}
+	_ list.ListResourceWithConfigure = &userListResource{}
+)
+
+// NewUserListResource returns a list resource that enumerates Passbolt users for terraform query.
+func NewUserListResource() list.ListResource {
+	return &userListResource{}
+}
+
+type userListResource struct {
+	client *tools.PassboltClient
+}
+
+type userListResourceModel struct {
+	UsernamePrefix  types.String `tfsdk:"username_prefix"`
+	Role            types.String `tfsdk:"role"`
+	IncludeInactive types.Bool   `tfsdk:"include_inactive"`
+}
+
+func (r *userListResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	r.client = configureListResourceClient(req, resp)
+}
+
+func (r *userListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *userListResource) ListResourceConfigSchema(
+	_ context.Context,
+	_ list.ListResourceSchemaRequest,
+	resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Lists Passbolt users, sorted by username. Deleted users are never listed.",
+		Attributes: map[string]schema.Attribute{
+			"username_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list users whose username (email) starts with this prefix, ignoring case.",
+			},
+			"role": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list users with this role, for example `admin` or `user`.",
+			},
+			"include_inactive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also list users who have not activated their account yet. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func (r *userListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	diags := ensureListLogin(ctx, r.client)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	var config userListResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	users, err := r.client.Users(ctx)
+	if err != nil {
+		diags.AddError("Error fetching users", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	prefix := strings.ToLower(config.UsernamePrefix.ValueString())
+	items := make([]listItem, 0, len(users))
+	for i := range users {
+		user := &users[i]
+		if user.Deleted || (!user.Active && !config.IncludeInactive.ValueBool()) {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(user.Username), prefix) {
+			continue
+		}
+		if !config.Role.IsNull() && userRoleName(user) != config.Role.ValueString() {
+			continue
+		}
+
+		items = append(items, listItem{
+			ID:          user.ID,
+			DisplayName: user.Username,
+			Attributes: map[string]any{
+				"username":   user.Username,
+				"first_name": userFirstName(user),
+				"last_name":  userLastName(user),
+				"role":       userRoleName(user),
+			},
+		})
+	}
+
+	slices.SortFunc(items, func(a, b listItem) int {
+		return cmp.Or(cmp.Compare(a.DisplayName, b.DisplayName), cmp.Compare(a.ID, b.ID))
+	})
+
+	stream.Results = streamListItems(ctx, req, items)
+}
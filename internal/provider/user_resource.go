@@ -6,7 +6,7 @@ import (
 
 	"terraform-provider-passbolt/tools"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &userResource{}
 	_ resource.ResourceWithConfigure   = &userResource{}
 	_ resource.ResourceWithImportState = &userResource{}
+	_ resource.ResourceWithIdentity    = &userResource{}
 )
 
 // NewUserResource returns a Terraform resource for managing Passbolt users.
@@ -58,7 +59,21 @@ func (r *userResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
+	importByIDOrIdentity(ctx, req, resp, func(id string) (string, diag.Diagnostics) {
+		return resolveUserImportID(ctx, r.client, id)
+	})
+}
+
+func (r *userResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = idIdentitySchema("The UUID of the Passbolt user.")
 }
 
 func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -100,6 +115,10 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 }
 
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan userModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -112,6 +131,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		plan.FirstName.ValueString(),
 		plan.LastName.ValueString(),
 	)
+	r.client.Invalidate(tools.CacheUsers)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating user", err.Error())
 
@@ -134,16 +154,23 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Keep First/Last name from plan — not returned from API
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state userModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	users, err := r.client.Client.GetUsers(ctx, nil)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+
+	users, err := r.client.Users(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Error fetching users", err.Error())
 
@@ -173,6 +200,10 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var plan userModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
@@ -189,6 +220,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		plan.FirstName.ValueString(),
 		plan.LastName.ValueString(),
 	)
+	r.client.Invalidate(tools.CacheUsers)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating user", err.Error())
 
@@ -198,9 +230,14 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.ID = state.ID
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !ensureLogin(ctx, r.client, &resp.Diagnostics) {
+		return
+	}
+
 	var state userModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -208,6 +245,7 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	err := helper.DeleteUser(ctx, r.client.Client, state.ID.ValueString())
+	r.client.Invalidate(tools.CacheUsers)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting user", err.Error())
 	}
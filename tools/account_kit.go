@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+)
+
+// AccountKit is the account-kit export of the Passbolt browser extension. The export is a base64 encoded,
+// armored PGP message signed by the user's key, wrapping this JSON document.
+type AccountKit struct {
+	Domain                 string `json:"domain"`
+	UserID                 string `json:"user_id"`
+	Username               string `json:"username"`
+	FirstName              string `json:"first_name"`
+	LastName               string `json:"last_name"`
+	UserPublicArmoredKey   string `json:"user_public_armored_key"`
+	UserPrivateArmoredKey  string `json:"user_private_armored_key"`
+	ServerPublicArmoredKey string `json:"server_public_armored_key"`
+}
+
+// ParseAccountKit decodes an account kit and checks that it is signed by the public key it contains.
+func ParseAccountKit(data string) (*AccountKit, error) {
+	armored, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(data), ""))
+	if err != nil {
+		return nil, fmt.Errorf("account kit is not base64 encoded: %w", err)
+	}
+
+	pgp := crypto.PGP()
+
+	// The signing key is part of the signed payload, so read the payload with an empty key ring first and verify
+	// it afterwards.
+	noKeys, err := crypto.NewKeyRing(nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading account kit: %w", err)
+	}
+
+	unverifiedVerifier, err := pgp.Verify().VerificationKeys(noKeys).New()
+	if err != nil {
+		return nil, fmt.Errorf("reading account kit: %w", err)
+	}
+
+	unverified, err := unverifiedVerifier.VerifyInline(armored, crypto.Armor)
+	if err != nil {
+		return nil, fmt.Errorf("reading account kit message: %w", err)
+	}
+
+	var kit AccountKit
+	if err := json.Unmarshal(unverified.Bytes(), &kit); err != nil {
+		return nil, fmt.Errorf("parsing account kit: %w", err)
+	}
+
+	if err := kit.validate(); err != nil {
+		return nil, err
+	}
+
+	publicKey, err := crypto.NewKeyFromArmored(kit.UserPublicArmoredKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing account kit public key: %w", err)
+	}
+
+	verifier, err := pgp.Verify().VerificationKey(publicKey).New()
+	if err != nil {
+		return nil, fmt.Errorf("verifying account kit: %w", err)
+	}
+
+	verified, err := verifier.VerifyInline(armored, crypto.Armor)
+	if err != nil {
+		return nil, fmt.Errorf("verifying account kit: %w", err)
+	}
+	if err := verified.SignatureError(); err != nil {
+		return nil, fmt.Errorf("account kit signature does not match its user key: %w", err)
+	}
+
+	return &kit, nil
+}
+
+func (k *AccountKit) validate() error {
+	var missing []string
+	if k.Domain == "" {
+		missing = append(missing, "domain")
+	}
+	if k.UserID == "" {
+		missing = append(missing, "user_id")
+	}
+	if k.UserPublicArmoredKey == "" {
+		missing = append(missing, "user_public_armored_key")
+	}
+	if k.UserPrivateArmoredKey == "" {
+		missing = append(missing, "user_private_armored_key")
+	}
+
+	if len(missing) > 0 {
+		return errors.New("account kit is missing " + strings.Join(missing, ", "))
+	}
+
+	return nil
+}
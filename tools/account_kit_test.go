@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/gopenpgp/v3/crypto"
+)
+
+func generateTestKey(t *testing.T) *crypto.Key {
+	t.Helper()
+
+	key, err := crypto.PGP().KeyGeneration().AddUserId("Terraform", "terraform@example.com").New().GenerateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	return key
+}
+
+// buildAccountKit exports kit the way the browser extension does, signed by signer.
+func buildAccountKit(t *testing.T, kit AccountKit, signer *crypto.Key) string {
+	t.Helper()
+
+	payload, err := json.Marshal(kit)
+	if err != nil {
+		t.Fatalf("marshaling account kit: %v", err)
+	}
+
+	handle, err := crypto.PGP().Sign().SigningKey(signer).New()
+	if err != nil {
+		t.Fatalf("creating signer: %v", err)
+	}
+
+	signed, err := handle.Sign(payload, crypto.Armor)
+	if err != nil {
+		t.Fatalf("signing account kit: %v", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(signed)
+}
+
+func testAccountKit(t *testing.T, key *crypto.Key) AccountKit {
+	t.Helper()
+
+	publicKey, err := key.GetArmoredPublicKey()
+	if err != nil {
+		t.Fatalf("exporting public key: %v", err)
+	}
+
+	privateKey, err := key.Armor()
+	if err != nil {
+		t.Fatalf("exporting private key: %v", err)
+	}
+
+	return AccountKit{
+		Domain:                "https://passbolt.example.com",
+		UserID:                "8e3874ae-4b40-590b-968a-418f704b9d9a",
+		Username:              "terraform@example.com",
+		UserPublicArmoredKey:  publicKey,
+		UserPrivateArmoredKey: privateKey,
+	}
+}
+
+func TestParseAccountKit(t *testing.T) {
+	t.Parallel()
+
+	key := generateTestKey(t)
+	want := testAccountKit(t, key)
+	exported := buildAccountKit(t, want, key)
+
+	// Exports copied from a terminal are often wrapped, so whitespace must not matter.
+	wrapped := exported[:40] + "\n" + exported[40:] + "\n"
+
+	kit, err := ParseAccountKit(wrapped)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kit.Domain != want.Domain || kit.UserID != want.UserID || kit.UserPrivateArmoredKey != want.UserPrivateArmoredKey {
+		t.Fatalf("expected %+v, got %+v", want, kit)
+	}
+}
+
+func TestParseAccountKitRejectsInvalidKits(t *testing.T) {
+	t.Parallel()
+
+	key := generateTestKey(t)
+	kit := testAccountKit(t, key)
+
+	missingUserID := kit
+	missingUserID.UserID = ""
+
+	tests := map[string]string{
+		"not base64":         "not an account kit!",
+		"not a pgp message":  base64.StdEncoding.EncodeToString([]byte("{}")),
+		"missing user id":    buildAccountKit(t, missingUserID, key),
+		"signed by stranger": buildAccountKit(t, kit, generateTestKey(t)),
+	}
+
+	for name, data := range tests {
+		if _, err := ParseAccountKit(data); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		} else if name == "signed by stranger" && !strings.Contains(err.Error(), "signature") {
+			t.Fatalf("%s: expected a signature error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CacheKey identifies a memoized lookup on PassboltClient.
+type CacheKey string
+
+// Lookups memoized for the lifetime of a provider instance, which is a single Terraform run.
+const (
+	CacheGroups        CacheKey = "groups"
+	CacheUsers         CacheKey = "users"
+	CacheFolders       CacheKey = "folders"
+	CacheResourceTypes CacheKey = "resource_types"
+)
+
+// lookupCache memoizes list lookups. Concurrent callers of a missing entry share one load, and failed loads are
+// not cached.
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[CacheKey]*cacheEntry
+}
+
+type cacheEntry struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// Memoize returns the cached value for key, calling load on a miss. Callers must treat the returned value as
+// read-only because it is shared with every other caller until the key is invalidated.
+func Memoize[T any](
+	ctx context.Context,
+	client *PassboltClient,
+	key CacheKey,
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	var zero T
+
+	for {
+		entry, owner := client.cache.entry(key)
+		if owner {
+			entry.value, entry.err = load(ctx)
+			if entry.err != nil {
+				client.cache.forget(key, entry)
+			}
+			close(entry.done)
+		} else {
+			select {
+			case <-entry.done:
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			}
+
+			if entry.err != nil {
+				// The load of another caller failed, possibly because its context ended. Load again.
+				continue
+			}
+		}
+
+		if entry.err != nil {
+			return zero, entry.err
+		}
+
+		value, ok := entry.value.(T)
+		if !ok {
+			return zero, fmt.Errorf("cached %s lookup has type %T", key, entry.value)
+		}
+
+		return value, nil
+	}
+}
+
+// Invalidate drops the cached lookups for keys. Call it after every mutation of the listed objects.
+func (c *PassboltClient) Invalidate(keys ...CacheKey) {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.cache.entries, key)
+	}
+}
+
+// Groups returns every group visible to the user, without memberships.
+func (c *PassboltClient) Groups(ctx context.Context) ([]api.Group, error) {
+	return memoizeSlice(ctx, c, CacheGroups, func(ctx context.Context) ([]api.Group, error) {
+		return c.Client.GetGroups(ctx, nil)
+	})
+}
+
+// Users returns every user visible to the user.
+func (c *PassboltClient) Users(ctx context.Context) ([]api.User, error) {
+	return memoizeSlice(ctx, c, CacheUsers, func(ctx context.Context) ([]api.User, error) {
+		return c.Client.GetUsers(ctx, nil)
+	})
+}
+
+// ResourceTypes returns the resource types supported by the server.
+func (c *PassboltClient) ResourceTypes(ctx context.Context) ([]api.ResourceType, error) {
+	return memoizeSlice(ctx, c, CacheResourceTypes, func(ctx context.Context) ([]api.ResourceType, error) {
+		return c.Client.GetResourceTypes(ctx, nil)
+	})
+}
+
+// memoizeSlice is Memoize for list lookups. It returns a copy of the cached slice so callers may reorder or append
+// to it.
+func memoizeSlice[T any](
+	ctx context.Context,
+	client *PassboltClient,
+	key CacheKey,
+	load func(ctx context.Context) ([]T, error),
+) ([]T, error) {
+	values, err := Memoize(ctx, client, key, load)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Clone(values), nil
+}
+
+// entry returns the entry for key, creating it when missing. owner is true when the caller created the entry and
+// must load it.
+func (c *lookupCache) entry(key CacheKey) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		return entry, false
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[CacheKey]*cacheEntry)
+	}
+
+	entry := &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+
+	return entry, true
+}
+
+// forget removes entry unless it was already replaced after an invalidation.
+func (c *lookupCache) forget(key CacheKey, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+}
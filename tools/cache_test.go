@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoizeSharesOneLoadBetweenConcurrentCallers(t *testing.T) {
+	t.Parallel()
+
+	client := &PassboltClient{}
+	release := make(chan struct{})
+	var loads atomic.Int32
+
+	load := func(context.Context) ([]string, error) {
+		loads.Add(1)
+		<-release
+
+		return []string{"ops"}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan []string, 8)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			value, err := Memoize(context.Background(), client, CacheGroups, load)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+
+				return
+			}
+			results <- value
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for value := range results {
+		if len(value) != 1 || value[0] != "ops" {
+			t.Fatalf("expected the cached lookup, got %q", value)
+		}
+	}
+	if loads.Load() != 1 {
+		t.Fatalf("expected a single load, got %d", loads.Load())
+	}
+}
+
+func TestMemoizeInvalidate(t *testing.T) {
+	t.Parallel()
+
+	client := &PassboltClient{}
+	var loads int
+	load := func(context.Context) (int, error) {
+		loads++
+
+		return loads, nil
+	}
+
+	ctx := context.Background()
+	first, _ := Memoize(ctx, client, CacheFolders, load)
+	cached, _ := Memoize(ctx, client, CacheFolders, load)
+	if first != 1 || cached != 1 {
+		t.Fatalf("expected the second lookup to hit the cache, got %d and %d", first, cached)
+	}
+
+	client.Invalidate(CacheUsers)
+	if value, _ := Memoize(ctx, client, CacheFolders, load); value != 1 {
+		t.Fatalf("expected invalidating another key to keep folders cached, got load %d", value)
+	}
+
+	client.Invalidate(CacheFolders)
+	if value, _ := Memoize(ctx, client, CacheFolders, load); value != 2 {
+		t.Fatalf("expected a reload after invalidation, got load %d", value)
+	}
+}
+
+func TestMemoizeDoesNotCacheFailures(t *testing.T) {
+	t.Parallel()
+
+	client := &PassboltClient{}
+	errUnavailable := errors.New("unavailable")
+	calls := 0
+	load := func(context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errUnavailable
+		}
+
+		return "ok", nil
+	}
+
+	ctx := context.Background()
+	if _, err := Memoize(ctx, client, CacheResourceTypes, load); !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected the load error, got %v", err)
+	}
+
+	value, err := Memoize(ctx, client, CacheResourceTypes, load)
+	if err != nil || value != "ok" {
+		t.Fatalf("expected the failed lookup to be retried, got %q, %v", value, err)
+	}
+}
+
+func TestMemoizeWaiterReloadsAfterOwnerFails(t *testing.T) {
+	t.Parallel()
+
+	client := &PassboltClient{}
+	ownerStarted := make(chan struct{})
+	releaseOwner := make(chan struct{})
+
+	ownerDone := make(chan error, 1)
+	go func() {
+		_, err := Memoize(context.Background(), client, CacheUsers, func(context.Context) (string, error) {
+			close(ownerStarted)
+			<-releaseOwner
+
+			return "", context.Canceled
+		})
+		ownerDone <- err
+	}()
+
+	<-ownerStarted
+
+	waiterDone := make(chan string, 1)
+	go func() {
+		value, _ := Memoize(context.Background(), client, CacheUsers, func(context.Context) (string, error) {
+			return "reloaded", nil
+		})
+		waiterDone <- value
+	}()
+
+	close(releaseOwner)
+
+	if err := <-ownerDone; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the owner to see its own error, got %v", err)
+	}
+	if value := <-waiterDone; value != "reloaded" {
+		t.Fatalf("expected the waiter to load again, got %q", value)
+	}
+}
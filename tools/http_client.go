@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// HTTPClientOptions configures the HTTP client used to talk to Passbolt.
+type HTTPClientOptions struct {
+	// CACertPEM holds PEM encoded CA certificates trusted in addition to the system pool.
+	CACertPEM string
+	// CACertFile is the path to a PEM encoded CA bundle trusted in addition to the system pool.
+	CACertFile string
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded certificate and key presented for mutual TLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+	// ProxyURL overrides the proxy taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+	// Timeout bounds each request, including reading the response body. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// NewHTTPClient builds an HTTP client from opts. It starts from the settings of http.DefaultTransport so that
+// unset options behave like the default client.
+func NewHTTPClient(opts HTTPClientOptions) (*http.Client, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("http.DefaultTransport is not an *http.Transport")
+	}
+
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify, //nolint:gosec // explicit opt-in by the user
+	}
+
+	if opts.CACertPEM != "" || opts.CACertFile != "" {
+		pool, err := caCertPool(opts.CACertPEM, opts.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if opts.ClientCertPEM != "" || opts.ClientKeyPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(opts.ClientCertPEM), []byte(opts.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.ProxyURL != "" {
+		proxy, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy URL %q must include a scheme and host", opts.ProxyURL)
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   opts.Timeout,
+	}, nil
+}
+
+// caCertPool returns the system certificate pool extended with the given PEM data and file.
+func caCertPool(caCertPEM, caCertFile string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if caCertFile != "" {
+		data, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("CA certificate file %q contains no PEM certificates", caCertFile)
+		}
+	}
+
+	if caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+		return nil, errors.New("CA certificate PEM contains no certificates")
+	}
+
+	return pool, nil
+}
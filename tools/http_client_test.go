@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func certificatePEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// newTestClientCertificate returns a self-signed client certificate and key in PEM form.
+func newTestClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling key: %v", err)
+	}
+
+	return cert, certificatePEM(cert), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestNewHTTPClientTrustsConfiguredCA(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(certificatePEM(server.Certificate())), 0o600); err != nil {
+		t.Fatalf("writing CA file: %v", err)
+	}
+
+	tests := map[string]HTTPClientOptions{
+		"ca pem":   {CACertPEM: certificatePEM(server.Certificate())},
+		"ca file":  {CACertFile: caFile},
+		"insecure": {InsecureSkipVerify: true},
+	}
+
+	for name, opts := range tests {
+		client, err := NewHTTPClient(opts)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("%s: expected request to succeed, got %v", name, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	client, err := NewHTTPClient(HTTPClientOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp, err := client.Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected the default client to reject the untrusted server certificate")
+	}
+}
+
+func TestNewHTTPClientPresentsClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	clientCert, certPEM, keyPEM := newTestClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPClientOptions{
+		CACertPEM:     certificatePEM(server.Certificate()),
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected mutual TLS request to succeed, got %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewHTTPClientRoutesThroughProxy(t *testing.T) {
+	t.Parallel()
+
+	proxied := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(HTTPClientOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := client.Get("http://passbolt.invalid/auth/login.json")
+	if err != nil {
+		t.Fatalf("expected request through the proxy to succeed, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := <-proxied; got != "http://passbolt.invalid/auth/login.json" {
+		t.Fatalf("expected the proxy to receive the Passbolt URL, got %q", got)
+	}
+}
+
+func TestNewHTTPClientAppliesTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewHTTPClient(HTTPClientOptions{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp, err := client.Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected the request to time out")
+	}
+}
+
+func TestNewHTTPClientRejectsInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]HTTPClientOptions{
+		"ca pem without certificates": {CACertPEM: "not a certificate"},
+		"missing ca file":             {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"client cert without key":     {ClientCertPEM: "not a certificate"},
+		"proxy without scheme":        {ProxyURL: "proxy.example.com:3128"},
+	}
+
+	for name, opts := range tests {
+		if _, err := NewHTTPClient(opts); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// passboltMFACookie is the cookie Passbolt sets once an MFA challenge has been answered.
+const passboltMFACookie = "passbolt_mfa"
+
+// TOTPCodeFunc returns the TOTP code used to answer a Passbolt MFA challenge.
+type TOTPCodeFunc func(ctx context.Context) (string, error)
+
+// TOTPSecretCode returns a TOTPCodeFunc that derives codes from a base32 TOTP seed.
+func TOTPSecretCode(secret string) TOTPCodeFunc {
+	return func(_ context.Context) (string, error) {
+		return helper.GenerateOTPCode(secret, time.Now())
+	}
+}
+
+// TOTPCommandCode returns a TOTPCodeFunc that runs an external command and reads the code from its output.
+// The first element is the executable, the remaining elements are passed as arguments without a shell.
+func TOTPCommandCode(command []string) TOTPCodeFunc {
+	return func(ctx context.Context) (string, error) {
+		if len(command) == 0 {
+			return "", errors.New("TOTP command is empty")
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("running TOTP command %q: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+		}
+
+		code := strings.TrimSpace(stdout.String())
+		if code == "" {
+			return "", fmt.Errorf("TOTP command %q returned no code", command[0])
+		}
+
+		return code, nil
+	}
+}
+
+// EnableTOTP installs an MFA callback that answers Passbolt TOTP challenges with codes from code. Passbolt
+// challenges the client again once the MFA cookie expires, and the callback then verifies with a fresh code.
+// Rejected codes are retried up to retries times, waiting retryDelay between attempts.
+func EnableTOTP(client *PassboltClient, code TOTPCodeFunc, retries uint, retryDelay time.Duration) {
+	client.Client.MFACallback = func(ctx context.Context, c *api.Client, res *api.APIResponse) (http.Cookie, error) {
+		var challenge api.MFAChallenge
+		if err := json.Unmarshal(res.Body, &challenge); err != nil {
+			return http.Cookie{}, fmt.Errorf("parsing MFA challenge: %w", err)
+		}
+		if challenge.Provider.TOTP == "" {
+			return http.Cookie{}, errors.New("server did not offer TOTP as an MFA provider")
+		}
+
+		var lastErr error
+		for attempt := uint(0); attempt <= retries; attempt++ {
+			if attempt > 0 {
+				if err := sleepContext(ctx, retryDelay); err != nil {
+					return http.Cookie{}, err
+				}
+			}
+
+			totp, err := code(ctx)
+			if err != nil {
+				return http.Cookie{}, fmt.Errorf("generating TOTP code: %w", err)
+			}
+
+			raw, _, err := c.DoCustomRequestAndReturnRawResponseV5(
+				ctx,
+				"POST",
+				"mfa/verify/totp.json",
+				api.MFAChallengeResponse{TOTP: totp},
+				nil,
+			)
+			if err != nil {
+				var apiErr *api.APIError
+				if !errors.As(err, &apiErr) {
+					return http.Cookie{}, fmt.Errorf("verifying TOTP code: %w", err)
+				}
+
+				lastErr = err
+
+				continue
+			}
+
+			for _, cookie := range raw.Cookies() {
+				if cookie.Name == passboltMFACookie {
+					return *cookie, nil
+				}
+			}
+
+			return http.Cookie{}, errors.New("passbolt accepted the TOTP code but returned no MFA cookie")
+		}
+
+		return http.Cookie{}, fmt.Errorf("TOTP verification failed after %d attempts: %w", retries+1, lastErr)
+	}
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,205 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+// mfaStandInServer imitates the parts of the Passbolt API that drive the TOTP challenge flow.
+type mfaStandInServer struct {
+	mu            sync.Mutex
+	validCookie   string
+	verifications int
+	rejections    int
+	acceptsCode   func(code string) bool
+}
+
+func (s *mfaStandInServer) expireMFA() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.validCookie = ""
+}
+
+func (s *mfaStandInServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/mfa/verify/totp.json" {
+		var answer api.MFAChallengeResponse
+		if err := json.NewDecoder(r.Body).Decode(&answer); err != nil || !s.acceptsCode(answer.TOTP) {
+			s.rejections++
+			writeStandInResponse(w, http.StatusBadRequest, "error", "/mfa/verify/totp.json", `{}`)
+
+			return
+		}
+
+		s.verifications++
+		s.validCookie = fmt.Sprintf("mfa-%d", s.verifications)
+		http.SetCookie(w, &http.Cookie{Name: passboltMFACookie, Value: s.validCookie})
+		writeStandInResponse(w, http.StatusOK, "success", "/mfa/verify/totp.json", `{}`)
+
+		return
+	}
+
+	cookie, err := r.Cookie(passboltMFACookie)
+	if err != nil || s.validCookie == "" || cookie.Value != s.validCookie {
+		writeStandInResponse(
+			w,
+			http.StatusForbidden,
+			"error",
+			"/mfa/verify/error.json",
+			`{"providers":{"totp":"/mfa/verify/totp.json"}}`,
+		)
+
+		return
+	}
+
+	writeStandInResponse(w, http.StatusOK, "success", r.URL.Path, `{"ok":true}`)
+}
+
+func writeStandInResponse(w http.ResponseWriter, status int, result, url, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(
+		w,
+		`{"header":{"status":%q,"code":%d,"url":%q,"message":""},"body":%s}`,
+		result,
+		status,
+		url,
+		body,
+	)
+}
+
+func newStandInClient(t *testing.T, server *httptest.Server) *PassboltClient {
+	t.Helper()
+
+	client, err := api.NewClient(server.Client(), "", server.URL, "", "")
+	if err != nil {
+		t.Fatalf("unexpected client error: %v", err)
+	}
+
+	return &PassboltClient{Client: client, URL: server.URL}
+}
+
+func TestEnableTOTPAnswersChallengeAndReverifiesAfterExpiry(t *testing.T) {
+	t.Parallel()
+
+	standIn := &mfaStandInServer{
+		acceptsCode: func(code string) bool {
+			// Accept the previous window as well so the test does not flake on a window boundary.
+			now := time.Now()
+			current, _ := helper.GenerateOTPCode(testTOTPSecret, now)
+			previous, _ := helper.GenerateOTPCode(testTOTPSecret, now.Add(-30*time.Second))
+
+			return code == current || code == previous
+		},
+	}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	client := newStandInClient(t, server)
+	EnableTOTP(client, TOTPSecretCode(testTOTPSecret), 0, 0)
+
+	ctx := context.Background()
+	if _, err := client.Client.DoCustomRequestV5(ctx, "GET", "/resources.json", nil, nil); err != nil {
+		t.Fatalf("expected challenged request to succeed, got %v", err)
+	}
+	if _, err := client.Client.DoCustomRequestV5(ctx, "GET", "/resources.json", nil, nil); err != nil {
+		t.Fatalf("expected request with MFA cookie to succeed, got %v", err)
+	}
+	if standIn.verifications != 1 {
+		t.Fatalf("expected 1 verification while the MFA cookie is valid, got %d", standIn.verifications)
+	}
+
+	standIn.expireMFA()
+
+	if _, err := client.Client.DoCustomRequestV5(ctx, "GET", "/resources.json", nil, nil); err != nil {
+		t.Fatalf("expected request after MFA expiry to succeed, got %v", err)
+	}
+	if standIn.verifications != 2 {
+		t.Fatalf("expected MFA to be verified again after expiry, got %d verifications", standIn.verifications)
+	}
+}
+
+func TestEnableTOTPRetriesRejectedCodes(t *testing.T) {
+	t.Parallel()
+
+	standIn := &mfaStandInServer{
+		acceptsCode: func(code string) bool { return code == "654321" },
+	}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	codes := []string{"000000", "654321"}
+	client := newStandInClient(t, server)
+	EnableTOTP(client, func(context.Context) (string, error) {
+		code := codes[0]
+		codes = codes[1:]
+
+		return code, nil
+	}, 1, 0)
+
+	if _, err := client.Client.DoCustomRequestV5(context.Background(), "GET", "/resources.json", nil, nil); err != nil {
+		t.Fatalf("expected retry with a fresh code to succeed, got %v", err)
+	}
+	if standIn.rejections != 1 || standIn.verifications != 1 {
+		t.Fatalf(
+			"expected 1 rejection and 1 verification, got %d and %d",
+			standIn.rejections,
+			standIn.verifications,
+		)
+	}
+}
+
+func TestEnableTOTPFailsAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	standIn := &mfaStandInServer{
+		acceptsCode: func(code string) bool { return code == "654321" },
+	}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	client := newStandInClient(t, server)
+	EnableTOTP(client, func(context.Context) (string, error) { return "000000", nil }, 2, 0)
+
+	if _, err := client.Client.DoCustomRequestV5(context.Background(), "GET", "/resources.json", nil, nil); err == nil {
+		t.Fatal("expected request to fail when every TOTP code is rejected")
+	}
+	if standIn.rejections != 3 {
+		t.Fatalf("expected 3 rejected attempts, got %d", standIn.rejections)
+	}
+}
+
+func TestTOTPCommandCode(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("echo is not an executable on Windows")
+	}
+
+	code, err := TOTPCommandCode([]string{"echo", " 123456 "})(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected command error: %v", err)
+	}
+	if code != "123456" {
+		t.Fatalf("expected code %q, got %q", "123456", code)
+	}
+
+	if _, err := TOTPCommandCode(nil)(context.Background()); err == nil {
+		t.Fatal("expected an empty command to be rejected")
+	}
+}
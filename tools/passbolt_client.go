@@ -4,16 +4,28 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/passbolt/go-passbolt/api"
 )
 
-// PassboltClient wraps the low-level API client and configuration.
+// PassboltClient wraps the low-level API client and configuration. It must not be copied after first use
+// because it holds the login state and the lookup cache shared by all resources of a run.
 type PassboltClient struct {
 	Client     *api.Client
 	URL        string
 	PrivateKey string
 	Password   string
+	// ExpectedUserID, when set, is the user the credentials must authenticate as, for example the owner of an
+	// account kit.
+	ExpectedUserID string
+	// ConfigError, when set, is returned by EnsureLogin instead of logging in. It records provider settings that
+	// were not known when the provider was configured.
+	ConfigError error
+
+	loginMu  sync.Mutex
+	loggedIn bool
+	cache    lookupCache
 }
 
 // Login authenticates the Passbolt client using its internal credentials.
@@ -24,3 +36,35 @@ func Login(ctx context.Context, client *PassboltClient) error {
 
 	return nil
 }
+
+// EnsureLogin logs the client in unless an earlier call already succeeded. Concurrent callers wait for a single
+// login, and a failed login is attempted again by the next call.
+func (c *PassboltClient) EnsureLogin(ctx context.Context) error {
+	if c.ConfigError != nil {
+		return c.ConfigError
+	}
+
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	if c.loggedIn {
+		return nil
+	}
+
+	if err := Login(ctx, c); err != nil {
+		return err
+	}
+
+	if c.ExpectedUserID != "" && c.Client.GetUserID() != c.ExpectedUserID {
+		return fmt.Errorf(
+			"login failed: %s authenticated as user %s, but the credentials belong to user %s",
+			c.URL,
+			c.Client.GetUserID(),
+			c.ExpectedUserID,
+		)
+	}
+
+	c.loggedIn = true
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestEnsureLoginRetriesFailedLogins(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login.json" {
+			attempts.Add(1)
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	privateKey, err := generateTestKey(t).Armor()
+	if err != nil {
+		t.Fatalf("exporting private key: %v", err)
+	}
+
+	apiClient, err := api.NewClient(server.Client(), "", server.URL, privateKey, "")
+	if err != nil {
+		t.Fatalf("unexpected client error: %v", err)
+	}
+	client := &PassboltClient{Client: apiClient, URL: server.URL}
+
+	for range 2 {
+		if err := client.EnsureLogin(context.Background()); err == nil {
+			t.Fatal("expected the login to fail")
+		}
+	}
+
+	if attempts.Load() != 2 {
+		t.Fatalf("expected a failed login to be attempted again, got %d attempts", attempts.Load())
+	}
+}
+
+func TestEnsureLoginReportsConfigError(t *testing.T) {
+	t.Parallel()
+
+	errUnknown := errors.New("base_url is unknown")
+	client := &PassboltClient{ConfigError: errUnknown}
+
+	if err := client.EnsureLogin(context.Background()); !errors.Is(err, errUnknown) {
+		t.Fatalf("expected the configuration error, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ResourceTypeSchema holds the compiled metadata and secret JSON schemas of a Passbolt resource type.
+type ResourceTypeSchema struct {
+	Slug string
+	// SecretIsString is set for resource types whose secret is a plain string instead of a JSON object.
+	SecretIsString bool
+
+	metadata *jsonschema.Schema
+	secret   *jsonschema.Schema
+}
+
+// CompileResourceTypeSchema compiles the JSON schemas in the definition of rType. Servers that return an empty
+// definition fall back to the schemas bundled with go-passbolt.
+func CompileResourceTypeSchema(rType api.ResourceType) (*ResourceTypeSchema, error) {
+	definition, err := resourceTypeDefinition(rType)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := compileSchema("urn:passbolt:schema:"+rType.Slug+":metadata", definition.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("compiling metadata schema of %s: %w", rType.Slug, err)
+	}
+
+	secretType, _ := definition.Secret["type"].(string)
+	schema := &ResourceTypeSchema{
+		Slug:           rType.Slug,
+		SecretIsString: secretType == "string",
+		metadata:       metadata,
+	}
+
+	if !schema.SecretIsString {
+		schema.secret, err = compileSchema("urn:passbolt:schema:"+rType.Slug+":secret", definition.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("compiling secret schema of %s: %w", rType.Slug, err)
+		}
+	}
+
+	return schema, nil
+}
+
+// ValidateMetadata validates decoded metadata fields.
+func (s *ResourceTypeSchema) ValidateMetadata(fields map[string]any) error {
+	if err := s.metadata.Validate(normalizeJSON(fields)); err != nil {
+		return fmt.Errorf("metadata does not match the %s schema: %w", s.Slug, err)
+	}
+
+	return nil
+}
+
+// ValidateSecret validates decoded secret fields. String secrets are passed as {"password": "..."}.
+func (s *ResourceTypeSchema) ValidateSecret(fields map[string]any) error {
+	if s.SecretIsString {
+		if _, ok := fields["password"].(string); !ok || len(fields) != 1 {
+			return fmt.Errorf("the %s secret is a plain string, pass it as {\"password\": \"...\"}", s.Slug)
+		}
+
+		return nil
+	}
+
+	if err := s.secret.Validate(normalizeJSON(fields)); err != nil {
+		return fmt.Errorf("secret does not match the %s schema: %w", s.Slug, err)
+	}
+
+	return nil
+}
+
+func resourceTypeDefinition(rType api.ResourceType) (api.ResourceTypeSchema, error) {
+	var schema api.ResourceTypeSchema
+
+	definition := rType.Definition
+	if string(definition) == "[]" || string(definition) == `"[]"` || len(definition) == 0 {
+		fallback, ok := api.ResourceSchemas[rType.Slug]
+		if !ok {
+			return schema, fmt.Errorf("resource type %s has no schema", rType.Slug)
+		}
+
+		definition = fallback
+	}
+
+	err := json.Unmarshal(definition, &schema)
+
+	// Some Passbolt versions return the definition as an escaped JSON string.
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		var encoded string
+		if err = json.Unmarshal(definition, &encoded); err == nil {
+			err = json.Unmarshal([]byte(encoded), &schema)
+		}
+	}
+	if err != nil {
+		return schema, fmt.Errorf("parsing schema of resource type %s: %w", rType.Slug, err)
+	}
+
+	return schema, nil
+}
+
+func compileSchema(url string, schema map[string]any) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, normalizeJSON(schema)); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(url)
+}
+
+// normalizeJSON round-trips value through encoding/json so that numbers and nested values have the types the
+// validator expects.
+func normalizeJSON(value any) any {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	normalized, err := jsonschema.UnmarshalJSON(bytes.NewReader(encoded))
+	if err != nil {
+		return value
+	}
+
+	return normalized
+}
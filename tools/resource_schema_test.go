@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+func TestCompileResourceTypeSchemaFallsBackToBundledSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := CompileResourceTypeSchema(api.ResourceType{Slug: "v5-default", Definition: json.RawMessage(`[]`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := schema.ValidateMetadata(map[string]any{"name": "db", "uris": []any{"https://db.example.com"}}); err != nil {
+		t.Fatalf("expected valid metadata: %v", err)
+	}
+	if err := schema.ValidateMetadata(map[string]any{"name": "db", "unknown": true}); err == nil {
+		t.Fatal("expected metadata with an unknown field to be rejected")
+	}
+	if err := schema.ValidateSecret(map[string]any{"password": "hunter2"}); err != nil {
+		t.Fatalf("expected valid secret: %v", err)
+	}
+	if err := schema.ValidateSecret(map[string]any{"description": "no password"}); err == nil {
+		t.Fatal("expected secret without the required password to be rejected")
+	}
+}
+
+func TestCompileResourceTypeSchemaReadsEscapedDefinition(t *testing.T) {
+	t.Parallel()
+
+	definition, err := json.Marshal(`{"resource":{"type":"object","required":["name"]},"secret":{"type":"string"}}`)
+	if err != nil {
+		t.Fatalf("encoding definition: %v", err)
+	}
+
+	schema, err := CompileResourceTypeSchema(api.ResourceType{Slug: "password-string", Definition: definition})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !schema.SecretIsString {
+		t.Fatal("expected a string secret")
+	}
+	if err := schema.ValidateMetadata(map[string]any{}); err == nil {
+		t.Fatal("expected metadata without name to be rejected")
+	}
+	if err := schema.ValidateSecret(map[string]any{"password": "hunter2"}); err != nil {
+		t.Fatalf("expected valid string secret: %v", err)
+	}
+	if err := schema.ValidateSecret(map[string]any{"password": "hunter2", "totp": "x"}); err == nil {
+		t.Fatal("expected extra fields to be rejected for string secrets")
+	}
+}
+
+func TestCompileResourceTypeSchemaRejectsUnknownSlugWithoutDefinition(t *testing.T) {
+	t.Parallel()
+
+	unknown := api.ResourceType{Slug: "v9-unknown", Definition: json.RawMessage(`[]`)}
+	if _, err := CompileResourceTypeSchema(unknown); err == nil {
+		t.Fatal("expected an error for a resource type without a schema")
+	}
+}
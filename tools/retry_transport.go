@@ -0,0 +1,325 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// passboltCSRFCookie is the cookie holding the CSRF token that Passbolt expects to be echoed in X-CSRF-Token.
+const passboltCSRFCookie = "csrfToken"
+
+// passboltSessionCookies are the session cookie names used across Passbolt versions.
+var passboltSessionCookies = map[string]bool{
+	"passbolt_session": true,
+	"CAKEPHP":          true,
+	"PHPSESSID":        true,
+}
+
+type reloginContextKey struct{}
+
+// RetryOptions configures RetryTransport.
+type RetryOptions struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// WaitMin and WaitMax bound the exponential backoff between attempts, and WaitMax also caps Retry-After.
+	WaitMin time.Duration
+	WaitMax time.Duration
+}
+
+// RetryTransport retries throttled and transiently failing Passbolt requests and re-authenticates when the
+// session expires.
+//
+// 429 responses are retried for every method because the server did not process the request. 502, 503 and 504
+// responses and network errors are only retried for idempotent methods. Waits grow exponentially with jitter,
+// unless the server sends Retry-After.
+//
+// A 401 response outside the authentication endpoints means the session expired: the transport calls Relogin
+// once and replays the request with the new session cookies.
+type RetryTransport struct {
+	Base    http.RoundTripper
+	Options RetryOptions
+	// Relogin authenticates again, usually through Login. It is set once the API client exists.
+	Relogin func(ctx context.Context) error
+
+	reloginMu  sync.Mutex
+	mu         sync.Mutex
+	generation uint64
+	cookies    map[string]string
+}
+
+// DefaultRetryOptions returns the retry settings used when the provider configuration does not override them.
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries: defaultMaxRetries,
+		WaitMin:    defaultRetryWaitMin,
+		WaitMax:    defaultRetryWaitMax,
+	}
+}
+
+// NewRetryTransport wraps base with retries configured by opts.
+func NewRetryTransport(base http.RoundTripper, opts RetryOptions) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RetryTransport{Base: base, Options: opts}
+}
+
+// EnableRelogin makes transport re-authenticate client through Login when its session expires.
+func EnableRelogin(transport *RetryTransport, client *PassboltClient) {
+	transport.Relogin = func(ctx context.Context) error {
+		return Login(ctx, client)
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	relogged := false
+
+	for attempt := 0; ; attempt++ {
+		generation := t.currentGeneration()
+
+		attemptReq, err := t.prepareAttempt(req, attempt > 0 || relogged)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.Base.RoundTrip(attemptReq)
+		if err == nil {
+			t.rememberCookies(resp)
+		}
+
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && !relogged && t.canRelogin(req) {
+			drainBody(resp)
+
+			if err := t.relogin(ctx, generation); err != nil {
+				return nil, fmt.Errorf("re-authenticating after session expiry: %w", err)
+			}
+
+			relogged = true
+			attempt--
+
+			continue
+		}
+
+		if attempt >= t.Options.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			drainBody(resp)
+		}
+
+		tflog.Debug(ctx, "Retrying Passbolt request", map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"status":  responseStatus(resp),
+			"error":   fmt.Sprint(err),
+		})
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// prepareAttempt returns the request to send. Replays get a fresh body and the latest session cookies.
+func (t *RetryTransport) prepareAttempt(req *http.Request, replay bool) (*http.Request, error) {
+	if !replay {
+		return req, nil
+	}
+
+	attemptReq := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("cannot replay request: body is not rewindable")
+		}
+
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewinding request body: %w", err)
+		}
+
+		attemptReq.Body = body
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.cookies) == 0 {
+		return attemptReq, nil
+	}
+
+	cookies := attemptReq.Cookies()
+	attemptReq.Header.Del("Cookie")
+
+	for _, cookie := range cookies {
+		if value, ok := t.cookies[cookie.Name]; ok {
+			cookie.Value = value
+		}
+
+		attemptReq.AddCookie(cookie)
+	}
+
+	if csrf, ok := t.cookies[passboltCSRFCookie]; ok && attemptReq.Header.Get("X-CSRF-Token") != "" {
+		attemptReq.Header.Set("X-CSRF-Token", csrf)
+	}
+
+	return attemptReq, nil
+}
+
+// rememberCookies records session and CSRF cookies so replayed requests can carry the values from a re-login.
+func (t *RetryTransport) rememberCookies(resp *http.Response) {
+	for _, cookie := range resp.Cookies() {
+		if !passboltSessionCookies[cookie.Name] && cookie.Name != passboltCSRFCookie {
+			continue
+		}
+
+		t.mu.Lock()
+		if t.cookies == nil {
+			t.cookies = make(map[string]string)
+		}
+		t.cookies[cookie.Name] = cookie.Value
+		t.mu.Unlock()
+	}
+}
+
+func (t *RetryTransport) currentGeneration() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.generation
+}
+
+// canRelogin reports whether a 401 response to req should trigger a new login.
+func (t *RetryTransport) canRelogin(req *http.Request) bool {
+	if t.Relogin == nil || req.Context().Value(reloginContextKey{}) != nil {
+		return false
+	}
+
+	return !strings.Contains(req.URL.Path, "/auth/")
+}
+
+// relogin logs in again unless a concurrent request already did so after generation was observed.
+func (t *RetryTransport) relogin(ctx context.Context, generation uint64) error {
+	t.reloginMu.Lock()
+	defer t.reloginMu.Unlock()
+
+	if t.currentGeneration() != generation {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Passbolt session expired, logging in again")
+
+	if err := t.Relogin(context.WithValue(ctx, reloginContextKey{}, true)); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.generation++
+	t.mu.Unlock()
+
+	return nil
+}
+
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	waitMax := t.Options.WaitMax
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(wait, waitMax)
+		}
+	}
+
+	wait := t.Options.WaitMin
+	for range attempt {
+		if wait >= waitMax {
+			break
+		}
+
+		wait *= 2
+	}
+	wait = min(wait, waitMax)
+
+	// Equal jitter keeps at least half of the computed wait while spreading out concurrent clients.
+	half := wait / 2
+
+	return half + rand.N(half+1)
+}
+
+// retryAfter parses a Retry-After header given either as delay seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func responseStatus(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+
+	return resp.StatusCode
+}
+
+func drainBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+}
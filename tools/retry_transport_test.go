@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(opts RetryOptions) (*http.Client, *RetryTransport) {
+	transport := NewRetryTransport(nil, opts)
+
+	return &http.Client{Transport: transport}, transport
+}
+
+func fastRetryOptions(maxRetries int) RetryOptions {
+	return RetryOptions{MaxRetries: maxRetries, WaitMin: time.Millisecond, WaitMax: 10 * time.Millisecond}
+}
+
+func TestRetryTransportRetriesTransientFailures(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newRetryTestClient(fastRetryOptions(3))
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("expected success on the third attempt, got status %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryTransportStopsAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, _ := newRetryTestClient(fastRetryOptions(2))
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway || calls.Load() != 3 {
+		t.Fatalf("expected the last 502 after 3 calls, got status %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryTransportOnlyRetriesUnprocessedPosts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	var bodies []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		first := len(bodies) == 1
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/unavailable":
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case first:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newRetryTestClient(fastRetryOptions(3))
+
+	resp, err := client.Post(server.URL+"/throttled", "application/json", strings.NewReader(`{"name":"db"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected the throttled POST to be retried, got status %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"name":"db"}` {
+		t.Fatalf("expected the POST body to be replayed, got %q", bodies)
+	}
+
+	resp, err = client.Post(server.URL+"/unavailable", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected a POST answered with 503 not to be retried, got %d calls", calls.Load())
+	}
+}
+
+// sessionStandInServer issues a new session on login and expires it on demand.
+type sessionStandInServer struct {
+	mu       sync.Mutex
+	session  string
+	logins   int
+	accepted []string
+}
+
+func (s *sessionStandInServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/auth/login.json" {
+		s.logins++
+		s.session = fmt.Sprintf("session-%d", s.logins)
+		http.SetCookie(w, &http.Cookie{Name: "passbolt_session", Value: s.session})
+		w.WriteHeader(http.StatusOK)
+
+		return
+	}
+
+	cookie, err := r.Cookie("passbolt_session")
+	if err != nil || cookie.Value != s.session {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	s.accepted = append(s.accepted, string(body))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *sessionStandInServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session = "expired"
+}
+
+func TestRetryTransportLogsInAgainWhenSessionExpires(t *testing.T) {
+	t.Parallel()
+
+	standIn := &sessionStandInServer{}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	client, transport := newRetryTestClient(fastRetryOptions(0))
+	transport.Relogin = func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/auth/login.json", nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		return resp.Body.Close()
+	}
+
+	if err := transport.Relogin(context.Background()); err != nil {
+		t.Fatalf("unexpected login error: %v", err)
+	}
+	standIn.expire()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodPut, server.URL+"/resources.json", strings.NewReader("update"))
+			req.AddCookie(&http.Cookie{Name: "passbolt_session", Value: "session-1"})
+
+			resp, err := client.Do(req)
+			if err != nil {
+				errs <- err
+
+				return
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				errs <- fmt.Errorf("unexpected status %d", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("expected the request to be replayed after logging in again, got %v", err)
+	}
+	if standIn.logins != 2 {
+		t.Fatalf("expected exactly one additional login for concurrent expired requests, got %d logins", standIn.logins)
+	}
+	if len(standIn.accepted) != 4 || standIn.accepted[0] != "update" {
+		t.Fatalf("expected 4 replayed requests with their body, got %q", standIn.accepted)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"empty":        {value: ""},
+		"seconds":      {value: "7", want: 7 * time.Second, wantOK: true},
+		"http date":    {value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		"past date":    {value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		"malformed":    {value: "soon"},
+		"negative int": {value: "-3"},
+	}
+
+	for name, tc := range tests {
+		got, ok := retryAfter(tc.value, now)
+		if got != tc.want || ok != tc.wantOK {
+			t.Fatalf("%s: expected (%s, %t), got (%s, %t)", name, tc.want, tc.wantOK, got, ok)
+		}
+	}
+}
+
+func TestRetryTransportBackoffIsCapped(t *testing.T) {
+	t.Parallel()
+
+	transport := NewRetryTransport(nil, RetryOptions{MaxRetries: 10, WaitMin: time.Second, WaitMax: 4 * time.Second})
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		got := transport.backoff(attempt, nil)
+		if got < want/2 || got > want {
+			t.Fatalf("attempt %d: expected a wait between %s and %s, got %s", attempt, want/2, want, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if got := transport.backoff(0, resp); got != 4*time.Second {
+		t.Fatalf("expected Retry-After to be capped at the maximum wait, got %s", got)
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// TestTOTPCode checks the RFC 6238 appendix B test vectors.
+func TestTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	seed := func(ascii string) string {
+		return base32.StdEncoding.EncodeToString([]byte(ascii))
+	}
+
+	tests := map[string]struct {
+		totp TOTP
+		when int64
+		want string
+	}{
+		"sha1": {
+			totp: TOTP{SecretKey: seed("12345678901234567890"), Algorithm: "SHA1", Digits: 8, Period: 30},
+			when: 59,
+			want: "94287082",
+		},
+		"sha256": {
+			totp: TOTP{SecretKey: seed("12345678901234567890123456789012"), Algorithm: "SHA256", Digits: 8, Period: 30},
+			when: 1111111109,
+			want: "68084774",
+		},
+		"sha512": {
+			totp: TOTP{
+				SecretKey: seed("1234567890123456789012345678901234567890123456789012345678901234"),
+				Algorithm: "SHA512",
+				Digits:    8,
+				Period:    30,
+			},
+			when: 20000000000,
+			want: "47863826",
+		},
+		"defaults": {
+			totp: TOTP{SecretKey: seed("12345678901234567890")},
+			when: 59,
+			want: "287082",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := test.totp.Code(time.Unix(test.when, 0))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTOTPCodeAcceptsFormattedSecrets(t *testing.T) {
+	t.Parallel()
+
+	plain := TOTP{SecretKey: "JBSWY3DPEHPK3PXP"}
+	formatted := TOTP{SecretKey: "jbsw y3dp ehpk 3pxp"}
+	when := time.Unix(1700000000, 0)
+
+	want, _ := plain.Code(when)
+	got, err := formatted.Code(when)
+	if err != nil || got != want {
+		t.Fatalf("expected %s, got %s, %v", want, got, err)
+	}
+}
+
+func TestTOTPCodeRejectsInvalidSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]TOTP{
+		"bad secret":    {SecretKey: "not base32!"},
+		"bad algorithm": {SecretKey: "JBSWY3DPEHPK3PXP", Algorithm: "MD5"},
+		"bad digits":    {SecretKey: "JBSWY3DPEHPK3PXP", Digits: 10},
+	}
+
+	for name, totp := range tests {
+		if _, err := totp.Code(time.Now()); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTOTPExpiry(t *testing.T) {
+	t.Parallel()
+
+	totp := TOTP{Period: 60}
+	if got := totp.Expiry(time.Unix(125, 0)); !got.Equal(time.Unix(180, 0)) {
+		t.Fatalf("expected the code to expire at the end of its window, got %v", got.Unix())
+	}
+}